@@ -0,0 +1,372 @@
+package mikrotik
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExportOptions is options of /export command
+type ExportOptions struct {
+	// Path of subtree to export, like /ip/firewall, whole configuration is exported if empty
+	Path string
+
+	Compact       bool
+	Verbose       bool
+	Terse         bool
+	HideSensitive bool // RouterOS v6
+	ShowSensitive bool // RouterOS v7
+}
+
+func (opts ExportOptions) args() (args []string) {
+	for _, flag := range []struct {
+		name string
+		set  bool
+	}{
+		{"compact", opts.Compact},
+		{"verbose", opts.Verbose},
+		{"terse", opts.Terse},
+		{"hide-sensitive", opts.HideSensitive},
+		{"show-sensitive", opts.ShowSensitive},
+	} {
+		if flag.set {
+			args = append(args, "="+flag.name+"=")
+		}
+	}
+
+	return
+}
+
+// Export returns configuration script, API does not return output of export,
+// so configuration is exported to temporary file which is read and removed then.
+// Export of any size is read on RouterOS v7.13 or later, older versions return only exports
+// up to FileContentsLimit
+func (mik *Mikrotik) Export(opts ExportOptions) (string, error) {
+	path := strings.TrimRight(opts.Path, "/")
+	name := fmt.Sprintf("export-%d", time.Now().UnixNano())
+
+	args := append([]string{"=file=" + name}, opts.args()...)
+	if _, err := mik.RunArgs(path+"/export", args...); err != nil {
+		return "", err
+	}
+
+	name += ".rsc"
+	defer mik.File.RemoveByName(name)
+
+	if err := mik.waitFile(name); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := mik.readFile(name, &b); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// waitFile wait until file appears and its size stops changing, router writes export file with a delay
+func (mik *Mikrotik) waitFile(name string) error {
+	size := -1
+	for i := 0; i < 60; i++ {
+		time.Sleep(500 * time.Millisecond)
+
+		var list []*File
+		if err := mik.File.Find("name="+name, &list); err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			continue
+		}
+
+		if list[0].Size > 0 && list[0].Size == size {
+			return nil
+		}
+		size = list[0].Size
+	}
+
+	return errors.New("export file " + name + " is not ready")
+}
+
+// Config is parsed configuration script, entries are grouped by menu path, like /ip/address
+type Config map[string][]*ConfigEntry
+
+// ConfigEntry is one command of configuration script
+type ConfigEntry struct {
+	Command string
+
+	// Find is selector of set/remove command, like `[ find default-name=ether1 ]` or `0`
+	Find string
+
+	Params map[string]string
+}
+
+// Key identify entry for diff: by selector for set commands, by name if entry has it, otherwise by all params
+func (e *ConfigEntry) Key() string {
+	if e.Find != "" {
+		return e.Command + " " + e.Find
+	}
+
+	// set without selector changes settings of menu, like /system identity
+	if e.Command == "set" {
+		return e.Command
+	}
+
+	if name, ok := e.Params["name"]; ok {
+		return e.Command + " name=" + name
+	}
+
+	return e.Command + " " + e.paramsString()
+}
+
+func (e *ConfigEntry) paramsString() string {
+	keys := make([]string, 0, len(e.Params))
+	for key := range e.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vals := make([]string, len(keys))
+	for i, key := range keys {
+		vals[i] = key + "=" + e.Params[key]
+	}
+
+	return strings.Join(vals, " ")
+}
+
+var configCommands = map[string]bool{
+	"add":     true,
+	"set":     true,
+	"remove":  true,
+	"enable":  true,
+	"disable": true,
+	"unset":   true,
+}
+
+// ParseConfig parse exported configuration script
+func ParseConfig(script string) (Config, error) {
+	conf := make(Config)
+
+	var path string
+	for n, line := range joinConfigLines(script) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tokens, err := splitConfigLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n+1, err)
+		}
+
+		if strings.HasPrefix(tokens[0], "/") {
+			var i int
+			path, i = configPath(tokens)
+			tokens = tokens[i:]
+		}
+
+		if len(tokens) == 0 {
+			continue
+		}
+
+		entry := &ConfigEntry{Command: tokens[0], Params: make(map[string]string)}
+		for _, token := range tokens[1:] {
+			i := strings.IndexByte(token, '=')
+			if i < 0 || strings.HasPrefix(token, "[") {
+				entry.Find = token
+				continue
+			}
+
+			entry.Params[token[:i]] = unquoteConfigValue(token[i+1:])
+		}
+
+		conf[path] = append(conf[path], entry)
+	}
+
+	return conf, nil
+}
+
+// configPath join path tokens, like `/ip firewall nat`, returns path and count of tokens
+func configPath(tokens []string) (string, int) {
+	path := strings.TrimRight(tokens[0], "/")
+	i := 1
+	for ; i < len(tokens); i++ {
+		token := tokens[i]
+		if configCommands[token] || strings.ContainsAny(token, "=[") {
+			break
+		}
+		path += "/" + token
+	}
+
+	return path, i
+}
+
+// joinConfigLines split script to lines, lines continued by trailing backslash are joined
+func joinConfigLines(script string) (lines []string) {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(script, "\r\n", "\n"), "\n") {
+		if strings.HasSuffix(line, "\\") {
+			b.WriteString(strings.TrimSuffix(line, "\\"))
+			continue
+		}
+
+		b.WriteString(line)
+		lines = append(lines, b.String())
+		b.Reset()
+	}
+
+	if b.Len() > 0 {
+		lines = append(lines, b.String())
+	}
+
+	return
+}
+
+// splitConfigLine split line by spaces, quoted strings and [ ] blocks are kept whole
+func splitConfigLine(line string) (tokens []string, err error) {
+	var b strings.Builder
+	var quoted, escaped bool
+	var brackets int
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && quoted:
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == '[' && !quoted:
+			brackets++
+		case c == ']' && !quoted:
+			brackets--
+		case c == ' ' && !quoted && brackets == 0:
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+			continue
+		}
+
+		b.WriteRune(c)
+	}
+
+	if quoted || brackets != 0 {
+		return nil, errors.New("unterminated quote or bracket")
+	}
+
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+
+	return
+}
+
+func unquoteConfigValue(val string) string {
+	if len(val) < 2 || val[0] != '"' || val[len(val)-1] != '"' {
+		return val
+	}
+	val = val[1 : len(val)-1]
+
+	var b strings.Builder
+	var escaped bool
+	for _, c := range val {
+		if !escaped && c == '\\' {
+			escaped = true
+			continue
+		}
+
+		if escaped {
+			escaped = false
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case '_':
+				c = ' '
+			}
+		}
+
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
+
+// ConfigChange is difference of one entry between two configurations
+type ConfigChange struct {
+	Path string
+	Key  string
+	Type string
+
+	Old *ConfigEntry
+	New *ConfigEntry
+}
+
+const (
+	ConfigChangeAdded   = "added"
+	ConfigChangeRemoved = "removed"
+	ConfigChangeChanged = "changed"
+)
+
+func (c ConfigChange) String() string {
+	return fmt.Sprintf("%s %s %s", c.Type, c.Path, c.Key)
+}
+
+// DiffConfig compare configurations entry by entry, changes are sorted by path
+func DiffConfig(a, b Config) (changes []ConfigChange) {
+	paths := make(map[string]bool)
+	for path := range a {
+		paths[path] = true
+	}
+	for path := range b {
+		paths[path] = true
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	for _, path := range sorted {
+		oldEntries := configEntriesByKey(a[path])
+		newEntries := configEntriesByKey(b[path])
+
+		for _, e := range a[path] {
+			key := e.Key()
+			newEntry, ok := newEntries[key]
+			if !ok {
+				changes = append(changes, ConfigChange{Path: path, Key: key, Type: ConfigChangeRemoved, Old: e})
+				continue
+			}
+
+			if e.paramsString() != newEntry.paramsString() {
+				changes = append(changes, ConfigChange{Path: path, Key: key, Type: ConfigChangeChanged, Old: e, New: newEntry})
+			}
+		}
+
+		for _, e := range b[path] {
+			key := e.Key()
+			if _, ok := oldEntries[key]; !ok {
+				changes = append(changes, ConfigChange{Path: path, Key: key, Type: ConfigChangeAdded, New: e})
+			}
+		}
+	}
+
+	return
+}
+
+func configEntriesByKey(entries []*ConfigEntry) map[string]*ConfigEntry {
+	m := make(map[string]*ConfigEntry, len(entries))
+	for _, e := range entries {
+		m[e.Key()] = e
+	}
+	return m
+}
@@ -0,0 +1,119 @@
+package mikrotik
+
+import (
+	"errors"
+	"io"
+	"strconv"
+)
+
+// FileContentsLimit is max size of file which can be written through API, RouterOS sets file by
+// `contents` property, which is limited to this size and is not binary safe
+const FileContentsLimit = 4095
+
+// ErrContentsTooLarge is returned on writing file larger than FileContentsLimit
+var ErrContentsTooLarge = errors.New("file is too large to be written through api")
+
+type files struct {
+	cmd
+}
+
+// Read copy contents of file to w, large files are read by chunks on RouterOS v7.13 or later,
+// older versions return only files up to FileContentsLimit
+func (c *files) Read(name string, w io.Writer) error {
+	return c.mikrotik.readFile(name, w)
+}
+
+// Write create or overwrite file by contents read from r, it is intended for small text files,
+// ErrContentsTooLarge is returned for files larger than FileContentsLimit
+func (c *files) Write(name string, r io.Reader) error {
+	return c.mikrotik.writeFile(name, r)
+}
+
+// RemoveByName remove file by its full name, like flash/script.rsc
+func (c *files) RemoveByName(name string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/remove", "=numbers="+name)
+	return err
+}
+
+// fileChunkSize is size of chunk for reading files by /file/read
+const fileChunkSize = 32 * 1024
+
+// readFile copy contents of router file to w by chunks of /file/read, which requires RouterOS v7.13 or later,
+// older versions fall back to `contents` property, which is empty for files larger than FileContentsLimit
+func (mik *Mikrotik) readFile(name string, w io.Writer) error {
+	for offset := 0; ; {
+		re, err := mik.RunArgs("/file/read",
+			"=file="+name,
+			"=offset="+strconv.Itoa(offset),
+			"=chunk-size="+strconv.Itoa(fileChunkSize),
+		)
+		if err != nil {
+			if offset == 0 && isNoSuchCommand(err) {
+				return mik.readFileContents(name, w)
+			}
+			return err
+		}
+
+		var data string
+		if len(re.Re) > 0 {
+			data = re.Re[0].Map["data"]
+		} else {
+			data = re.Done.Map["data"]
+		}
+
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+
+		offset += len(data)
+		if len(data) < fileChunkSize {
+			return nil
+		}
+	}
+}
+
+// readFileContents copy `contents` property of router file to w, it is empty for files larger than
+// FileContentsLimit, so error is returned for them
+func (mik *Mikrotik) readFileContents(name string, w io.Writer) error {
+	re, err := mik.RunArgs("/file/print", "=.proplist=size,contents", "?name="+name)
+	if err != nil {
+		return err
+	}
+
+	if len(re.Re) == 0 {
+		return errors.New("file " + name + " is not found")
+	}
+
+	contents := re.Re[0].Map["contents"]
+	if size, _ := strconv.Atoi(re.Re[0].Map["size"]); contents == "" && size > 0 {
+		return errors.New("file " + name + " is too large to be read through api of this RouterOS version")
+	}
+
+	_, err = io.WriteString(w, contents)
+	return err
+}
+
+// writeFile create or overwrite router file by contents read from r, size is limited by FileContentsLimit
+func (mik *Mikrotik) writeFile(name string, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, FileContentsLimit+1))
+	if err != nil {
+		return err
+	}
+
+	if len(data) > FileContentsLimit {
+		return ErrContentsTooLarge
+	}
+
+	re, err := mik.RunArgs("/file/print", "=.proplist=.id", "?name="+name)
+	if err != nil {
+		return err
+	}
+
+	if len(re.Re) > 0 {
+		_, err = mik.RunArgs("/file/set", "=.id="+re.Re[0].Map[".id"], "=contents="+string(data))
+		return err
+	}
+
+	_, err = mik.RunArgs("/file/add", "=name="+name, "=contents="+string(data))
+	return err
+}
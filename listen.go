@@ -0,0 +1,112 @@
+package mikrotik
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	routeros "gopkg.in/routeros.v2"
+)
+
+// Listener receives replies of command which runs until it is cancelled,
+// like `follow`, `monitor-traffic` or `scan`
+type Listener struct {
+	// C is closed when context is done or command is finished
+	C <-chan Values
+
+	reply  *routeros.ListenReply
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Listen run command and send its replies to listener channel until ctx is done or command is finished
+func (mik *Mikrotik) Listen(ctx context.Context, cmd string, args ...string) (*Listener, error) {
+	mik.connMutex.Lock()
+	toRun := append([]string{cmd}, args...)
+	log.Tracef("[Listen] %v", toRun)
+	reply, err := mik.Conn.ListenArgs(toRun)
+	mik.connMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	c := make(chan Values)
+	l := &Listener{
+		C:      c,
+		reply:  reply,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go l.run(ctx, c, mik.debug)
+
+	return l, nil
+}
+
+func (l *Listener) run(ctx context.Context, c chan<- Values, debug bool) {
+	defer close(l.done)
+	defer close(c)
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.stop()
+			return
+
+		case sen, ok := <-l.reply.Chan():
+			if !ok {
+				l.err = l.reply.Err()
+				return
+			}
+
+			if debug {
+				log.Debug(sen)
+			}
+
+			select {
+			case c <- ValuesFrom(sen.Map):
+			case <-ctx.Done():
+				l.stop()
+				return
+			}
+		}
+	}
+}
+
+// each run fn for each reply in separate goroutine until listener is done, then done is called,
+// typed streams decode replies and send them to their channel in fn and close the channel in done
+func (l *Listener) each(fn func(vals Values), done func()) {
+	go func() {
+		defer done()
+
+		for vals := range l.C {
+			fn(vals)
+		}
+	}()
+}
+
+// stop send /cancel to router, replies received until cancel is done are dropped
+func (l *Listener) stop() {
+	go func() {
+		for range l.reply.Chan() {
+		}
+	}()
+
+	if _, err := l.reply.Cancel(); err != nil {
+		l.err = err
+	}
+}
+
+// Cancel stop command and wait until listener is done
+func (l *Listener) Cancel() error {
+	l.cancel()
+	<-l.done
+	return l.err
+}
+
+// Err returns error of command, it waits until listener is done
+func (l *Listener) Err() error {
+	<-l.done
+	return l.err
+}
@@ -1,8 +1,12 @@
 package mikrotik
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,7 +21,7 @@ func Dial(addr, user, pass string) (*Mikrotik, error) {
 		return nil, err
 	}
 
-	mik := &Mikrotik{Conn: c}
+	mik := &Mikrotik{Conn: c, addr: addr, user: user, pass: pass}
 	mik.setMikrotikCommands()
 
 	return mik, nil
@@ -30,7 +34,7 @@ func DialTimeout(addr, user, pass string, timeout time.Duration) (*Mikrotik, err
 		return nil, err
 	}
 
-	mik := &Mikrotik{Conn: c}
+	mik := &Mikrotik{Conn: c, addr: addr, user: user, pass: pass, timeout: timeout}
 	mik.setMikrotikCommands()
 
 	return mik, nil
@@ -41,10 +45,21 @@ type Mikrotik struct {
 	Conn      *routeros.Client
 	connMutex sync.Mutex
 
-	IP        ip
-	System    system
-	Interface netinterface
-	PPP       ppp
+	addr    string
+	user    string
+	pass    string
+	timeout time.Duration
+
+	IP          ip
+	System      system
+	Interface   netinterface
+	PPP         ppp
+	User        user
+	Log         logs
+	File        files
+	Certificate certificate
+	CAPsMAN     capsman
+	Tool        tool
 
 	debug bool
 }
@@ -57,6 +72,28 @@ func (mik *Mikrotik) Close() {
 	mik.Conn.Close()
 }
 
+// Reconnect close current connection and dial to router again with the same credentials
+func (mik *Mikrotik) Reconnect() error {
+	var c *routeros.Client
+	var err error
+	if mik.timeout > 0 {
+		c, err = routeros.DialTimeout(mik.addr, mik.user, mik.pass, mik.timeout)
+	} else {
+		c, err = routeros.Dial(mik.addr, mik.user, mik.pass)
+	}
+	if err != nil {
+		return err
+	}
+
+	mik.connMutex.Lock()
+	defer mik.connMutex.Unlock()
+
+	mik.Conn.Close()
+	mik.Conn = c
+
+	return nil
+}
+
 func (mik *Mikrotik) setMikrotikCommands() {
 	mik.IP = ip{
 		Address: cmd{mikrotik: mik, path: "/ip/address"},
@@ -74,8 +111,27 @@ func (mik *Mikrotik) setMikrotikCommands() {
 		NTP: ntp{
 			Client: cfg{mikrotik: mik, path: "/system/ntp/client"},
 		},
-		Routerboard: printable{mikrotik: mik, path: "/system/routerboard"},
-		Resource:    printable{mikrotik: mik, path: "/system/resource"},
+		Routerboard: routerboard{printable{mikrotik: mik, path: "/system/routerboard"}},
+		Resource:    resource{printable{mikrotik: mik, path: "/system/resource"}},
+		Script: script{
+			cmd: cmd{mikrotik: mik, path: "/system/script"},
+			Job: cmd{mikrotik: mik, path: "/system/script/job"},
+		},
+		Scheduler: cmd{mikrotik: mik, path: "/system/scheduler"},
+		Logging: logging{
+			cmd:    cmd{mikrotik: mik, path: "/system/logging"},
+			Action: cmd{mikrotik: mik, path: "/system/logging/action"},
+		},
+		Backup:  backup{mikrotik: mik, path: "/system/backup"},
+		Clock:   clock{mikrotik: mik, path: "/system/clock"},
+		Health:  health{mikrotik: mik, path: "/system/health"},
+		License: license{mikrotik: mik, path: "/system/license"},
+		Package: systemPackage{
+			cmd: cmd{mikrotik: mik, path: "/system/package"},
+			Update: packageUpdate{
+				cfg: cfg{mikrotik: mik, path: "/system/package/update"},
+			},
+		},
 	}
 
 	mik.Interface = netinterface{
@@ -86,9 +142,28 @@ func (mik *Mikrotik) setMikrotikCommands() {
 		Wireless: wireless{
 			cmd:              cmd{mikrotik: mik, path: "/interface/wireless"},
 			SecurityProfiles: cmd{mikrotik: mik, path: "/interface/wireless/security-profiles"},
+			RegistrationTable: registrationTable{
+				cmd{mikrotik: mik, path: "/interface/wireless/registration-table"},
+			},
+			AccessList:  cmd{mikrotik: mik, path: "/interface/wireless/access-list"},
+			ConnectList: cmd{mikrotik: mik, path: "/interface/wireless/connect-list"},
+		},
+		WiFi:      newWiFi(mik, "/interface/wifi"),
+		WiFiWave2: newWiFi(mik, "/interface/wifiwave2"),
+		Lte: lte{
+			mikrotik: mik,
+			path:     "/interface/lte",
+			APN:      cmd{mikrotik: mik, path: "/interface/lte/apn"},
+		},
+		Ethernet: ethernet{
+			cmd: cmd{mikrotik: mik, path: "/interface/ethernet"},
+			Switch: ethernetSwitch{
+				cmd:  cmd{mikrotik: mik, path: "/interface/ethernet/switch"},
+				Port: cmd{mikrotik: mik, path: "/interface/ethernet/switch/port"},
+				VLAN: cmd{mikrotik: mik, path: "/interface/ethernet/switch/vlan"},
+				Rule: cmd{mikrotik: mik, path: "/interface/ethernet/switch/rule"},
+			},
 		},
-		Lte:      lte{mikrotik: mik, path: "/interface/lte"},
-		Ethernet: cmd{mikrotik: mik, path: "/interface/ethernet"},
 	}
 
 	mik.PPP = ppp{
@@ -96,6 +171,43 @@ func (mik *Mikrotik) setMikrotikCommands() {
 		Secret:     cmd{mikrotik: mik, path: "/ppp/secret"},
 		L2tpSecret: cmd{mikrotik: mik, path: "/ppp/l2tp-secret"},
 		Profile:    cmd{mikrotik: mik, path: "/ppp/profile"},
+		Active:     pppActive{cmd{mikrotik: mik, path: "/ppp/active"}},
+	}
+
+	mik.Log = logs{mikrotik: mik, path: "/log"}
+	mik.File = files{cmd{mikrotik: mik, path: "/file"}}
+	mik.Certificate = certificate{cmd{mikrotik: mik, path: "/certificate"}}
+
+	mik.Tool = tool{
+		SMS: sms{
+			mikrotik: mik,
+			path:     "/tool/sms",
+			Inbox:    cmd{mikrotik: mik, path: "/tool/sms/inbox"},
+		},
+		Torch:    torch{mikrotik: mik, path: "/tool/torch"},
+		Sniffer:  sniffer{mikrotik: mik, path: "/tool/sniffer"},
+		Netwatch: cmd{mikrotik: mik, path: "/tool/netwatch"},
+		Email:    email{mikrotik: mik, path: "/tool/e-mail"},
+		Fetch:    fetch{mikrotik: mik, path: "/tool/fetch"},
+	}
+
+	mik.CAPsMAN = capsman{
+		Manager:           cfg{mikrotik: mik, path: "/caps-man/manager"},
+		Interface:         cmd{mikrotik: mik, path: "/caps-man/interface"},
+		Configuration:     cmd{mikrotik: mik, path: "/caps-man/configuration"},
+		Channel:           cmd{mikrotik: mik, path: "/caps-man/channel"},
+		Datapath:          cmd{mikrotik: mik, path: "/caps-man/datapath"},
+		Security:          cmd{mikrotik: mik, path: "/caps-man/security"},
+		Provisioning:      cmd{mikrotik: mik, path: "/caps-man/provisioning"},
+		RemoteCap:         remoteCap{cmd{mikrotik: mik, path: "/caps-man/remote-cap"}},
+		RegistrationTable: registrationTable{cmd{mikrotik: mik, path: "/caps-man/registration-table"}},
+	}
+
+	mik.User = user{
+		cmd:     cmd{mikrotik: mik, path: "/user"},
+		Group:   cmd{mikrotik: mik, path: "/user/group"},
+		Active:  printable{mikrotik: mik, path: "/user/active"},
+		SSHKeys: sshKeys{cmd{mikrotik: mik, path: "/user/ssh-keys"}},
 	}
 }
 
@@ -103,24 +215,174 @@ func (mik *Mikrotik) Ping(addr string, count int) *Ping {
 	return &Ping{mikrotik: mik, Address: addr, Count: count}
 }
 
+func (ping *Ping) args() []string {
+	args := ToArgs(ping)
+	if ping.DoNotFragment {
+		args = append(args, "=do-not-fragment=")
+	}
+	return args
+}
+
+// Start send Count echo requests and wait for all replies, error is returned if no reply is received
 func (ping *Ping) Start() ([]*PingResponse, error) {
-	re, err := ping.mikrotik.RunArgs("/ping", ToArgs(ping)...)
+	re, err := ping.mikrotik.RunArgs("/ping", ping.args()...)
 	if err != nil {
 		return nil, err
 	}
 
 	var pingResp []*PingResponse
 	for _, resp := range re.Re {
-		ValuesFrom(resp.Map).To(&pingResp)
+		if err := ValuesFrom(resp.Map).To(&pingResp); err != nil {
+			return pingResp, err
+		}
 	}
 
-	if len(pingResp) > 0 && pingResp[0].PacketLoss == 100 {
+	if NewPingStats(pingResp).Received == 0 {
 		return pingResp, errors.New("timeout")
 	}
 
 	return pingResp, nil
 }
 
+// Stream send echo requests until Count requests are sent or ctx is done, fn is called on each reply as it arrives,
+// statistics of all replies is returned
+func (ping *Ping) Stream(ctx context.Context, fn func(*PingResponse)) (*PingStats, error) {
+	l, err := ping.mikrotik.Listen(ctx, "/ping", ping.args()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var pingResp []*PingResponse
+	for vals := range l.C {
+		var resp *PingResponse
+		if err := vals.To(&resp); err != nil {
+			l.Cancel()
+			return NewPingStats(pingResp), err
+		}
+
+		pingResp = append(pingResp, resp)
+		if fn != nil {
+			fn(resp)
+		}
+	}
+
+	stats := NewPingStats(pingResp)
+	if err := l.Err(); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+func (mik *Mikrotik) Traceroute(addr string) *Traceroute {
+	return &Traceroute{mikrotik: mik, Address: addr}
+}
+
+// Start run traceroute with Count rounds, 3 if Count is not set, and returns hops of last round
+func (tr *Traceroute) Start() ([]*TracerouteHop, error) {
+	if tr.Count == 0 {
+		tr.Count = 3
+	}
+
+	re, err := tr.mikrotik.RunArgs("/tool/traceroute", ToArgs(tr)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var hops []*TracerouteHop
+	var section string
+	for _, resp := range re.Re {
+		if s := resp.Map[".section"]; s != section {
+			section = s
+			hops = nil
+		}
+
+		if err := ValuesFrom(resp.Map).To(&hops); err != nil {
+			return nil, err
+		}
+	}
+
+	return hops, nil
+}
+
+// Stream run traceroute until ctx is done or Count rounds are done, hops of each round are sent to channel
+func (tr *Traceroute) Stream(ctx context.Context) (<-chan []*TracerouteHop, error) {
+	l, err := tr.mikrotik.Listen(ctx, "/tool/traceroute", ToArgs(tr)...)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := make(chan []*TracerouteHop)
+	var hops []*TracerouteHop
+	var section string
+	send := func() {
+		if len(hops) == 0 {
+			return
+		}
+
+		select {
+		case rounds <- hops:
+		case <-ctx.Done():
+		}
+	}
+
+	l.each(func(vals Values) {
+		if s := vals.Get(".section"); s != section {
+			send()
+			section = s
+			hops = nil
+		}
+
+		if err := vals.To(&hops); err != nil {
+			log.Debugf("[Traceroute] %v", err)
+		}
+	}, func() {
+		send()
+		close(rounds)
+	})
+
+	return rounds, nil
+}
+
+// BandwidthTest run test with bandwidth-test server until test Duration is passed or ctx is done,
+// fn is called on each progress sample, last sample is returned as result
+func (mik *Mikrotik) BandwidthTest(ctx context.Context, opts BandwidthTest, fn func(*BandwidthTestSample)) (*BandwidthTestSample, error) {
+	l, err := mik.Listen(ctx, "/tool/bandwidth-test", ToArgs(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *BandwidthTestSample
+	for vals := range l.C {
+		var sample *BandwidthTestSample
+		if err := vals.To(&sample); err != nil {
+			l.Cancel()
+			return last, err
+		}
+
+		last = sample
+		if fn != nil {
+			fn(sample)
+		}
+	}
+
+	if err := l.Err(); err != nil {
+		return last, err
+	}
+
+	return last, nil
+}
+
+// Execute script source and return its output, output capture requires RouterOS v7
+func (mik *Mikrotik) Execute(source string) (string, error) {
+	re, err := mik.RunArgs("/execute", "=script="+source, "=as-string=")
+	if err != nil {
+		return "", err
+	}
+
+	return re.Done.Map["ret"], nil
+}
+
 // Run one line command on mikrotik by api
 func (mik *Mikrotik) Run(cmd string) (*routeros.Reply, error) {
 	mik.connMutex.Lock()
@@ -315,8 +577,271 @@ type system struct {
 
 	Identity    identity
 	NTP         ntp
-	Routerboard printable
-	Resource    printable
+	Routerboard routerboard
+	Resource    resource
+	Script      script
+	Scheduler   cmd
+	Package     systemPackage
+	Clock       clock
+	Health      health
+	License     license
+	Logging     logging
+	Backup      backup
+}
+
+type backup struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+// Save create backup file with name, empty password and encryption means not encrypted backup,
+// encryption is aes-sha256 or rc4
+func (b *backup) Save(name, password, encryption string) error {
+	args := []string{"=name=" + name}
+	if password == "" && encryption == "" {
+		args = append(args, "=dont-encrypt=yes")
+	}
+	if password != "" {
+		args = append(args, "=password="+password)
+	}
+	if encryption != "" {
+		args = append(args, "=encryption="+encryption)
+	}
+
+	_, err := b.mikrotik.RunArgs(b.path+"/save", args...)
+	return err
+}
+
+// Load restore backup from file, router will be rebooted. Backup is binary and usually larger than
+// FileContentsLimit, so it can not be uploaded through API, upload it by FTP or SFTP before load
+func (b *backup) Load(name, password string) error {
+	_, err := b.mikrotik.RunArgs(b.path+"/load", "=name="+backupFileName(name), "=password="+password)
+	return err
+}
+
+// Download backup file from router to w
+func (b *backup) Download(name string, w io.Writer) error {
+	return b.mikrotik.readFile(backupFileName(name), w)
+}
+
+func backupFileName(name string) string {
+	if strings.HasSuffix(name, ".backup") {
+		return name
+	}
+	return name + ".backup"
+}
+
+type logging struct {
+	cmd
+
+	Action cmd
+}
+
+// Reboot router, connection will be closed by router, so error of closed connection is not returned
+func (s *system) Reboot() error {
+	_, err := s.mikrotik.RunArgs(s.path + "/reboot")
+	if isConnClosed(err) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown router, connection will be closed by router, so error of closed connection is not returned
+func (s *system) Shutdown() error {
+	_, err := s.mikrotik.RunArgs(s.path + "/shutdown")
+	if isConnClosed(err) {
+		return nil
+	}
+	return err
+}
+
+// ResetConfiguration reset router configuration with passed options and reboot it,
+// error of connection closed by router is not returned
+func (s *system) ResetConfiguration(opts ResetConfiguration) error {
+	_, err := s.mikrotik.RunArgs(s.path+"/reset-configuration", ToArgs(opts)...)
+	if isConnClosed(err) {
+		return nil
+	}
+	return err
+}
+
+type resource struct {
+	printable
+}
+
+func (r *resource) Get() (*Resource, error) {
+	var resp *Resource
+	err := r.Print(&resp)
+	return resp, err
+}
+
+// CPU returns load of each cpu core
+func (r *resource) CPU() ([]*ResourceCPU, error) {
+	var list []*ResourceCPU
+	err := r.mikrotik.Print(r.path+"/cpu/print", &list)
+	return list, err
+}
+
+type clock struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+func (c *clock) Get() (*Clock, error) {
+	var resp *Clock
+	err := c.mikrotik.Print(c.path+"/print", &resp)
+	return resp, err
+}
+
+// Time returns current router time in its time zone
+func (c *clock) Time() (time.Time, error) {
+	resp, err := c.Get()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return resp.ToTime()
+}
+
+// SetTime set router date and time, passed time is converted to router time zone
+func (c *clock) SetTime(t time.Time) error {
+	resp, err := c.Get()
+	if err != nil {
+		return err
+	}
+
+	loc, err := resp.Location()
+	if err != nil {
+		return err
+	}
+
+	t = t.In(loc)
+	_, err = c.mikrotik.RunArgs(c.path+"/set",
+		"=date="+strings.ToLower(t.Format("Jan/02/2006")),
+		"=time="+t.Format("15:04:05"),
+	)
+	return err
+}
+
+// SetTimeZone set time zone by name, like Europe/Riga, autodetect is disabled
+func (c *clock) SetTimeZone(name string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/set", "=time-zone-autodetect=no", "=time-zone-name="+name)
+	return err
+}
+
+type health struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+// Get returns health sensors, legacy flat format and v7 list of name/value items are both supported
+func (c *health) Get() (*Health, error) {
+	re, err := c.mikrotik.Run(c.path + "/print")
+	if err != nil {
+		return nil, err
+	}
+
+	sensors := make(map[string]string)
+	for _, resp := range re.Re {
+		if c.mikrotik.debug {
+			log.Debug(resp)
+		}
+
+		name, ok := resp.Map["name"]
+		if ok {
+			sensors[name] = resp.Map["value"]
+			continue
+		}
+
+		for key, val := range resp.Map {
+			sensors[key] = val
+		}
+	}
+
+	var h *Health
+	if err := ValuesFrom(sensors).To(&h); err != nil {
+		return nil, err
+	}
+	h.Sensors = sensors
+
+	return h, nil
+}
+
+type license struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+func (c *license) Get() (*License, error) {
+	var resp *License
+	err := c.mikrotik.Print(c.path+"/print", &resp)
+	return resp, err
+}
+
+type routerboard struct {
+	printable
+}
+
+func (rb *routerboard) Get() (*Routerboard, error) {
+	var resp *Routerboard
+	err := rb.Print(&resp)
+	return resp, err
+}
+
+// Upgrade RouterBOARD firmware to version of installed packages, applied after reboot
+func (rb *routerboard) Upgrade() error {
+	_, err := rb.mikrotik.RunArgs(rb.path + "/upgrade")
+	return err
+}
+
+type systemPackage struct {
+	cmd
+
+	Update packageUpdate
+}
+
+type packageUpdate struct {
+	cfg
+}
+
+// CheckForUpdates request latest version from update server for current channel
+func (c *packageUpdate) CheckForUpdates() (*PackageUpdate, error) {
+	if _, err := c.mikrotik.RunArgs(c.path + "/check-for-updates"); err != nil {
+		return nil, err
+	}
+
+	var update *PackageUpdate
+	err := c.Get(&update)
+	return update, err
+}
+
+// SetChannel set update channel: stable, long-term, testing or development
+func (c *packageUpdate) SetChannel(channel string) error {
+	return c.Set("channel", channel)
+}
+
+// Download latest packages without installing them
+func (c *packageUpdate) Download() error {
+	_, err := c.mikrotik.RunArgs(c.path + "/download")
+	return err
+}
+
+// Install download latest packages and reboot router to install them
+func (c *packageUpdate) Install() error {
+	_, err := c.mikrotik.RunArgs(c.path + "/install")
+	return err
+}
+
+type script struct {
+	cmd
+
+	Job cmd
+}
+
+// Run script by name or id
+func (c *script) Run(name string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/run", "=number="+name)
+	return err
 }
 
 type ntp struct {
@@ -361,8 +886,10 @@ type netinterface struct {
 	SSTPClient cmd
 	SSTPServer cmd
 	Wireless   wireless
+	WiFi       wifi
+	WiFiWave2  wifi
 	Lte        lte
-	Ethernet   cmd
+	Ethernet   ethernet
 }
 
 func (c *netinterface) List(v interface{}) error {
@@ -407,12 +934,73 @@ func (c *netinterface) Comment(id, comment string) error {
 	return c.mikrotik.Comment(c.path+"/comment", id, comment)
 }
 
+// MonitorTraffic send traffic samples of interfaces to channel every second until ctx is done
+func (c *netinterface) MonitorTraffic(ctx context.Context, names ...string) (<-chan *TrafficSample, error) {
+	l, err := c.mikrotik.Listen(ctx, c.path+"/monitor-traffic", "=interface="+strings.Join(names, ","))
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make(chan *TrafficSample)
+	l.each(func(vals Values) {
+		var sample *TrafficSample
+		if err := vals.To(&sample); err != nil {
+			log.Debugf("[MonitorTraffic] %v", err)
+			return
+		}
+
+		select {
+		case samples <- sample:
+		case <-ctx.Done():
+		}
+	}, func() { close(samples) })
+
+	return samples, nil
+}
+
 type wireless struct {
 	// mikrotik *Mikrotik
 	// path     string
 	cmd
 
-	SecurityProfiles cmd
+	SecurityProfiles  cmd
+	RegistrationTable registrationTable
+	AccessList        cmd
+	ConnectList       cmd
+}
+
+// Block add access list rule which denies authentication of client by MAC address on all interfaces
+func (c *wireless) Block(mac, comment string) error {
+	args := []string{"=mac-address=" + mac, "=authentication=no", "=forwarding=no"}
+	if comment != "" {
+		args = append(args, "=comment="+comment)
+	}
+
+	_, err := c.mikrotik.RunArgs(c.AccessList.path+"/add", args...)
+	return err
+}
+
+// registrationTable is list of connected wireless clients, items can be only listed and removed
+type registrationTable struct {
+	cmd
+}
+
+// Disconnect remove client from registration table by MAC address, client is able to connect again
+func (c *registrationTable) Disconnect(mac string) error {
+	var list []*struct {
+		ID string `mikrotik:".id"`
+	}
+	if err := c.Find("mac-address="+mac, &list); err != nil {
+		return err
+	}
+
+	for _, reg := range list {
+		if err := c.Remove(reg.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (c *wireless) Scan(name, duration string) (APlist []*WirelessAP, err error) {
@@ -447,10 +1035,179 @@ func (c *wireless) contains(list []*WirelessAP, ap *WirelessAP) bool {
 	return false
 }
 
-type lte struct {
-	mikrotik *Mikrotik
-	path     string
-}
+// SignalHistorySize is number of last signal samples kept in SignalHistory of access point by ScanStream
+const SignalHistorySize = 20
+
+// ScanStream scan for access points until ctx is done, AP is sent to channel on every update,
+// signal of last SignalHistorySize updates is collected to SignalHistory
+func (c *wireless) ScanStream(ctx context.Context, name string) (<-chan *WirelessAP, error) {
+	l, err := c.mikrotik.Listen(ctx, c.path+"/scan", "=.id="+name)
+	if err != nil {
+		return nil, err
+	}
+
+	aps := make(chan *WirelessAP)
+	history := make(map[string][]int)
+	l.each(func(vals Values) {
+		var ap *WirelessAP
+		if err := vals.To(&ap); err != nil {
+			log.Debugf("[ScanStream] %v", err)
+			return
+		}
+
+		key := ap.Address + ap.SSID
+		signals := append(history[key], ap.SIG)
+		if len(signals) > SignalHistorySize {
+			signals = signals[len(signals)-SignalHistorySize:]
+		}
+		history[key] = signals
+		ap.SignalHistory = append([]int(nil), signals...)
+
+		select {
+		case aps <- ap:
+		case <-ctx.Done():
+		}
+	}, func() { close(aps) })
+
+	return aps, nil
+}
+
+// FrequencyMonitor send usage of frequencies to channel until ctx is done
+func (c *wireless) FrequencyMonitor(ctx context.Context, name string) (<-chan *FrequencyUsage, error) {
+	l, err := c.mikrotik.Listen(ctx, c.path+"/frequency-monitor", "=numbers="+name)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(chan *FrequencyUsage)
+	l.each(func(vals Values) {
+		var u *FrequencyUsage
+		if err := vals.To(&u); err != nil {
+			log.Debugf("[FrequencyMonitor] %v", err)
+			return
+		}
+
+		select {
+		case usage <- u:
+		case <-ctx.Done():
+		}
+	}, func() { close(usage) })
+
+	return usage, nil
+}
+
+// SpectralScan send spectral samples to channel until ctx is done, freqRange may be empty for current channel,
+// or be like 2400-2500
+func (c *wireless) SpectralScan(ctx context.Context, name, freqRange string) (<-chan *SpectralSample, error) {
+	args := []string{"=numbers=" + name}
+	if freqRange != "" {
+		args = append(args, "=range="+freqRange)
+	}
+
+	l, err := c.mikrotik.Listen(ctx, c.path+"/spectral-scan", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make(chan *SpectralSample)
+	l.each(func(vals Values) {
+		var sample *SpectralSample
+		if err := vals.To(&sample); err != nil {
+			log.Debugf("[SpectralScan] %v", err)
+			return
+		}
+
+		select {
+		case samples <- sample:
+		case <-ctx.Done():
+		}
+	}, func() { close(samples) })
+
+	return samples, nil
+}
+
+// wifi is RouterOS v7 wifi package, the same tree is used by wifiwave2 package
+type wifi struct {
+	cmd
+
+	Configuration     cmd
+	Security          cmd
+	Channel           cmd
+	Datapath          cmd
+	RegistrationTable registrationTable
+}
+
+func newWiFi(mik *Mikrotik, path string) wifi {
+	return wifi{
+		cmd:               cmd{mikrotik: mik, path: path},
+		Configuration:     cmd{mikrotik: mik, path: path + "/configuration"},
+		Security:          cmd{mikrotik: mik, path: path + "/security"},
+		Channel:           cmd{mikrotik: mik, path: path + "/channel"},
+		Datapath:          cmd{mikrotik: mik, path: path + "/datapath"},
+		RegistrationTable: registrationTable{cmd{mikrotik: mik, path: path + "/registration-table"}},
+	}
+}
+
+// isNoSuchCommand reports whether err is trap of router about unknown command, e.g. package is not installed
+func isNoSuchCommand(err error) bool {
+	var devErr *routeros.DeviceError
+	return errors.As(err, &devErr) && strings.HasPrefix(devErr.Sentence.Map["message"], "no such command")
+}
+
+// WirelessClients returns connected clients from registration table of wifi, wifiwave2 or legacy wireless package,
+// the first package which is installed on router is used
+func (c *netinterface) WirelessClients() ([]*WirelessClient, error) {
+	for _, pkg := range []wifi{c.WiFi, c.WiFiWave2} {
+		var list []*WiFiRegistration
+		if err := pkg.RegistrationTable.List(&list); err != nil {
+			if isNoSuchCommand(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		clients := make([]*WirelessClient, len(list))
+		for i, reg := range list {
+			clients[i] = &WirelessClient{
+				Package:    pkg.path,
+				Interface:  reg.Interface,
+				MacAddress: reg.MacAddress,
+				SSID:       reg.SSID,
+				Signal:     reg.Signal,
+				RxRate:     reg.RxRate,
+				TxRate:     reg.TxRate,
+				Uptime:     reg.Uptime,
+			}
+		}
+		return clients, nil
+	}
+
+	var list []*WirelessRegistration
+	if err := c.Wireless.RegistrationTable.List(&list); err != nil {
+		return nil, err
+	}
+
+	clients := make([]*WirelessClient, len(list))
+	for i, reg := range list {
+		clients[i] = &WirelessClient{
+			Package:    c.Wireless.path,
+			Interface:  reg.Interface,
+			MacAddress: reg.MacAddress,
+			Signal:     reg.Signal(),
+			RxRate:     reg.RxRate,
+			TxRate:     reg.TxRate,
+			Uptime:     reg.Uptime,
+		}
+	}
+	return clients, nil
+}
+
+type lte struct {
+	mikrotik *Mikrotik
+	path     string
+
+	APN cmd
+}
 
 func (l *lte) Set(id string, v interface{}) error {
 	path := l.path
@@ -475,39 +1232,509 @@ func (l *lte) List(v interface{}) error {
 	return l.mikrotik.Print(l.path+"/print", v)
 }
 
+// Monitor send lte info samples of interface by id to channel until ctx is done,
+// samples are sent not often than interval, zero interval means every sample.
+// Every sample is compared with previous one, changes of registration status and cell are sent
+// as separate events regardless of interval.
+func (l *lte) Monitor(ctx context.Context, id string, interval time.Duration) (<-chan *LteEvent, error) {
+	listener, err := l.mikrotik.Listen(ctx, l.path+"/info", "=.id="+id)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *LteEvent)
+	send := func(event *LteEvent) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	var prev *LteInfo
+	var last time.Time
+	listener.each(func(vals Values) {
+		var info *LteInfo
+		if err := vals.To(&info); err != nil {
+			log.Debugf("[Monitor] %v", err)
+			return
+		}
+
+		if interval == 0 || time.Since(last) >= interval {
+			send(&LteEvent{Type: LteEventSample, Info: info, Prev: prev})
+			last = time.Now()
+		}
+
+		for _, event := range lteEvents(prev, info) {
+			send(event)
+		}
+		prev = info
+	}, func() { close(events) })
+
+	return events, nil
+}
+
+// lteEvents returns events of changes between previous and current samples
+func lteEvents(prev, info *LteInfo) []*LteEvent {
+	var events []*LteEvent
+	if prev == nil {
+		return events
+	}
+
+	if prev.RegistrationStatus != info.RegistrationStatus {
+		events = append(events, &LteEvent{Type: LteEventRegistration, Info: info, Prev: prev})
+	}
+
+	if prev.CurrentCellID != info.CurrentCellID || prev.PhyCellID != info.PhyCellID {
+		events = append(events, &LteEvent{Type: LteEventHandover, Info: info, Prev: prev})
+	}
+
+	return events
+}
+
+// ATChat send AT command to modem of interface by id and returns modem output
+func (l *lte) ATChat(id, input string) (string, error) {
+	re, err := l.mikrotik.RunArgs(l.path+"/at-chat", "=.id="+id, "=input="+input, "=wait=yes")
+	if err != nil {
+		return "", err
+	}
+
+	for _, resp := range re.Re {
+		if output, ok := resp.Map["output"]; ok {
+			return output, nil
+		}
+	}
+
+	return re.Done.Map["output"], nil
+}
+
+// USSD send USSD request, like *100#, and returns text of response
+func (l *lte) USSD(id, code string) (string, error) {
+	output, err := l.ATChat(id, `AT+CUSD=1,"`+code+`",15`)
+	if err != nil {
+		return "", err
+	}
+
+	// +CUSD: 0,"response text",15
+	i := strings.Index(output, `"`)
+	j := strings.LastIndex(output, `"`)
+	if i < 0 || j <= i {
+		return output, nil
+	}
+
+	return output[i+1 : j], nil
+}
+
+// ethernet uses cmd for list and set, ethernet ports are physical, so Add and Remove are rejected by router
 type ethernet struct {
-	mikrotik *Mikrotik
-	path     string
+	cmd
+
+	Switch ethernetSwitch
 }
 
-// func (e *ethernet) Name() error {
-// 	var resp struct{ Name string }
-// 	err := poe.mikrotik.Print(poe.path+"/print", &resp)
+// ethernetSwitch is a switch chip settings of CRS and hEX like devices
+type ethernetSwitch struct {
+	cmd
 
-// 	return resp.Name, err
-// }
+	Port cmd
+	VLAN cmd
+	Rule cmd
+}
 
-// func (l *ethernet) PoEOutAutoOn() error {
-// 	res, err := l.mikrotik.RunArgs(l.path+"/poe-out", "=.id="+id, "=once=")
-// 	if err != nil {
-// 		return err
-// 	}
+// Monitor returns link status of ethernet interface by name, includes SFP diagnostics for SFP ports
+func (e *ethernet) Monitor(name string) (*EthernetMonitor, error) {
+	re, err := e.mikrotik.RunArgs(e.path+"/monitor", "=numbers="+name, "=once=")
+	if err != nil {
+		return nil, err
+	}
 
-// 	err = l.mikrotik.ParseResponce(res, lteInfo)
-// 	if err != nil {
-// 		return err
-// 	}
+	var resp *EthernetMonitor
+	err = e.mikrotik.ParseResponce(re, &resp)
+	return resp, err
+}
 
-// 	return nil
-// }
+// SetPoEOut set PoE out mode of port by id: auto-on, forced-on or off
+func (e *ethernet) SetPoEOut(id, mode string) error {
+	_, err := e.mikrotik.RunArgs(e.path+"/set", "=.id="+id, "=poe-out="+mode)
+	return err
+}
 
-// func (l *ethernet) List(v interface{}) error {
-// 	return l.mikrotik.Print(l.path+"/print", v)
-// }
+// SetLink set auto negotiation, speed and duplex of port by id, flags are always sent, so unlike Set
+// it can disable auto negotiation and full duplex; empty speed is not changed
+func (e *ethernet) SetLink(id, speed string, autoNegotiation, fullDuplex bool) error {
+	autoNeg, duplex := "no", "no"
+	if autoNegotiation {
+		autoNeg = "yes"
+	}
+	if fullDuplex {
+		duplex = "yes"
+	}
+
+	args := []string{"=.id=" + id, "=auto-negotiation=" + autoNeg, "=full-duplex=" + duplex}
+	if speed != "" {
+		args = append(args, "=speed="+speed)
+	}
+
+	_, err := e.mikrotik.RunArgs(e.path+"/set", args...)
+	return err
+}
+
+// PoEMonitor returns PoE out status of port by name
+func (e *ethernet) PoEMonitor(name string) (*PoEMonitor, error) {
+	re, err := e.mikrotik.RunArgs(e.path+"/poe/monitor", "=numbers="+name, "=once=")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *PoEMonitor
+	err = e.mikrotik.ParseResponce(re, &resp)
+	return resp, err
+}
+
+// PowerCyclePoE turn off PoE out of port by name for duration, used to reboot powered device
+func (e *ethernet) PowerCyclePoE(name string, duration time.Duration) error {
+	_, err := e.mikrotik.RunArgs(e.path+"/poe/power-cycle", "=numbers="+name, "=duration="+FormatDuration(duration))
+	return err
+}
 
 type ppp struct {
 	AAA        cfg
 	L2tpSecret cmd
 	Profile    cmd
 	Secret     cmd
+	Active     pppActive
+}
+
+// pppActive is list of connected ppp sessions, items can be only listed and removed
+type pppActive struct {
+	cmd
+}
+
+// DisconnectByName remove all active sessions of user with passed name
+func (c *pppActive) DisconnectByName(name string) error {
+	var list []*PPPActive
+	if err := c.Find("name="+name, &list); err != nil {
+		return err
+	}
+
+	for _, active := range list {
+		if err := c.Remove(active.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type user struct {
+	cmd
+
+	Group   cmd
+	Active  printable
+	SSHKeys sshKeys
+}
+
+// SetPassword change password of user by id
+func (c *user) SetPassword(id, password string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/set", "=.id="+id, "=password="+password)
+	return err
+}
+
+type sshKeys struct {
+	cmd
+}
+
+// Import public key for user from file already uploaded to the router
+func (c *sshKeys) Import(user, publicKeyFile string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/import", "=user="+user, "=public-key-file="+publicKeyFile)
+	return err
+}
+
+// logs is a /log, it is only readable
+type logs struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+func (c *logs) List(v interface{}) error {
+	return c.mikrotik.Print(c.path+"/print", v)
+}
+
+func (c *logs) Find(where string, v interface{}) error {
+	re, err := c.mikrotik.RunArgs(c.path+"/print", "?"+where)
+	if err != nil {
+		return err
+	}
+
+	return c.mikrotik.ParseResponce(re, v)
+}
+
+// Follow send new log entries to channel until ctx is done
+func (c *logs) Follow(ctx context.Context) (<-chan *LogEntry, error) {
+	l, err := c.mikrotik.Listen(ctx, c.path+"/print", "=follow-only=")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan *LogEntry)
+	l.each(func(vals Values) {
+		if vals.Get(".dead") == "true" {
+			return
+		}
+
+		var entry *LogEntry
+		if err := vals.To(&entry); err != nil {
+			log.Debugf("[Follow] %v", err)
+			return
+		}
+
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+		}
+	}, func() { close(entries) })
+
+	return entries, nil
+}
+
+type certificate struct {
+	cmd
+}
+
+// Sign certificate template by id with CA certificate, empty ca means self-signed certificate
+func (c *certificate) Sign(id, ca string) error {
+	args := []string{"=.id=" + id}
+	if ca != "" {
+		args = append(args, "=ca="+ca)
+	}
+
+	_, err := c.mikrotik.RunArgs(c.path+"/sign", args...)
+	return err
+}
+
+// Import PEM or PKCS12 certificate or key from file already uploaded to the router
+func (c *certificate) Import(fileName, passphrase, name string) error {
+	args := []string{"=file-name=" + fileName, "=passphrase=" + passphrase}
+	if name != "" {
+		args = append(args, "=name="+name)
+	}
+
+	_, err := c.mikrotik.RunArgs(c.path+"/import", args...)
+	return err
+}
+
+// ImportFrom upload certificate from r to temporary file and import it, it is suitable for PEM files,
+// ErrContentsTooLarge is returned for files larger than FileContentsLimit, binary PKCS12 files
+// should be uploaded by FTP or SFTP and imported by Import
+func (c *certificate) ImportFrom(r io.Reader, passphrase, name string) error {
+	fileName := fmt.Sprintf("cert-%d", time.Now().UnixNano())
+	if err := c.mikrotik.writeFile(fileName, r); err != nil {
+		return err
+	}
+	defer c.mikrotik.File.RemoveByName(fileName)
+
+	return c.Import(fileName, passphrase, name)
+}
+
+// Export certificate to file, type is pem or pkcs12, private key is exported only with passphrase
+func (c *certificate) Export(id, typ, passphrase, fileName string) error {
+	args := []string{"=.id=" + id, "=type=" + typ, "=file-name=" + fileName}
+	if passphrase != "" {
+		args = append(args, "=export-passphrase="+passphrase)
+	}
+
+	_, err := c.mikrotik.RunArgs(c.path+"/export-certificate", args...)
+	return err
+}
+
+// SetTrusted mark certificate as trusted or not
+func (c *certificate) SetTrusted(id string, trusted bool) error {
+	value := "no"
+	if trusted {
+		value = "yes"
+	}
+
+	_, err := c.mikrotik.RunArgs(c.path+"/set", "=.id="+id, "=trusted="+value)
+	return err
+}
+
+type capsman struct {
+	Manager           cfg
+	Interface         cmd
+	Configuration     cmd
+	Channel           cmd
+	Datapath          cmd
+	Security          cmd
+	Provisioning      cmd
+	RemoteCap         remoteCap
+	RegistrationTable registrationTable
+}
+
+// remoteCap is list of CAPs connected to CAPsMAN
+type remoteCap struct {
+	cmd
+}
+
+// Provision CAP by id according to provisioning rules
+func (c *remoteCap) Provision(id string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/provision", "=.id="+id)
+	return err
+}
+
+// Upgrade RouterOS of CAP by id to version of CAPsMAN packages
+func (c *remoteCap) Upgrade(id string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/upgrade", "=.id="+id)
+	return err
+}
+
+type tool struct {
+	SMS      sms
+	Torch    torch
+	Sniffer  sniffer
+	Netwatch cmd
+	Email    email
+	Fetch    fetch
+}
+
+type fetch struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+// Run download or upload file by url, with Output user downloaded data is returned in result.
+// Fetch reports progress in several replies, fields of all replies are merged to result
+func (c *fetch) Run(opts Fetch) (*FetchResult, error) {
+	re, err := c.mikrotik.RunArgs(c.path, ToArgs(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]string)
+	for _, resp := range re.Re {
+		for k, v := range resp.Map {
+			merged[k] = v
+		}
+	}
+
+	var result *FetchResult
+	if err := ValuesFrom(merged).To(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type email struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+// Send e-mail by server configured in /tool/e-mail
+func (c *email) Send(to, subject, body string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/send", "=to="+to, "=subject="+subject, "=body="+body)
+	return err
+}
+
+type torch struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+// Start torch on interface until ctx is done, flows of each second are sent to channel
+// sorted by sum of rx and tx rates, so first flows are top talkers
+func (c *torch) Start(ctx context.Context, opts Torch) (<-chan []*TorchFlow, error) {
+	l, err := c.mikrotik.Listen(ctx, c.path, ToArgs(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(chan []*TorchFlow)
+	var flows []*TorchFlow
+	var section string
+	send := func() {
+		if len(flows) == 0 {
+			return
+		}
+
+		sort.Slice(flows, func(i, j int) bool {
+			return flows[i].Rx+flows[i].Tx > flows[j].Rx+flows[j].Tx
+		})
+
+		select {
+		case snapshots <- flows:
+		case <-ctx.Done():
+		}
+	}
+
+	l.each(func(vals Values) {
+		if s := vals.Get(".section"); s != section {
+			send()
+			section = s
+			flows = nil
+		}
+
+		if err := vals.To(&flows); err != nil {
+			log.Debugf("[Torch] %v", err)
+		}
+	}, func() { close(snapshots) })
+
+	return snapshots, nil
+}
+
+type sniffer struct {
+	mikrotik *Mikrotik
+	path     string
+}
+
+func (c *sniffer) Get() (*SnifferSettings, error) {
+	var resp *SnifferSettings
+	err := c.mikrotik.Print(c.path+"/print", &resp)
+	return resp, err
+}
+
+// Configure set filters, file and streaming settings of sniffer,
+// StreamingEnabled and FilterStream are always sent, so they can be disabled
+func (c *sniffer) Configure(settings SnifferSettings) error {
+	streaming, filterStream := "no", "no"
+	if settings.StreamingEnabled {
+		streaming = "yes"
+	}
+	if settings.FilterStream {
+		filterStream = "yes"
+	}
+
+	// false values are skipped by ToArgs, flags are sent explicitly below
+	settings.StreamingEnabled, settings.FilterStream = false, false
+
+	args := append(ToArgs(settings), "=streaming-enabled="+streaming, "=filter-stream="+filterStream)
+	_, err := c.mikrotik.RunArgs(c.path+"/set", args...)
+	return err
+}
+
+func (c *sniffer) Start() error {
+	_, err := c.mikrotik.RunArgs(c.path + "/start")
+	return err
+}
+
+func (c *sniffer) Stop() error {
+	_, err := c.mikrotik.RunArgs(c.path + "/stop")
+	return err
+}
+
+// Save sniffed packets from memory to file on router
+func (c *sniffer) Save(fileName string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/save", "=file-name="+fileName)
+	return err
+}
+
+type sms struct {
+	mikrotik *Mikrotik
+	path     string
+
+	Inbox cmd
+}
+
+// Send SMS message by modem of port, like lte1
+func (c *sms) Send(port, phoneNumber, message string) error {
+	_, err := c.mikrotik.RunArgs(c.path+"/send", "=port="+port, "=phone-number="+phoneNumber, "=message="+message)
+	return err
 }
@@ -1,7 +1,11 @@
 package mikrotik
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -334,3 +338,791 @@ func TestPing(t *testing.T) {
 		t.Logf("%+v", r)
 	}
 }
+
+func TestPPPActive(t *testing.T) {
+	var list []*PPPActive
+	if err := mikrotik.PPP.Active.List(&list); err != nil {
+		t.Error(err)
+	}
+
+	for _, active := range list {
+		t.Logf("%+v", active)
+	}
+
+	if err := mikrotik.PPP.Active.DisconnectByName("test-secret"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUser(t *testing.T) {
+	group := UserGroup{
+		Name:   "test-api",
+		Policy: []string{UserPolicyAPI, UserPolicyRead},
+	}
+	if err := mikrotik.User.Group.Add(&group); err != nil {
+		t.Error(err)
+	}
+
+	u := User{
+		Name:     "test-user",
+		Group:    group.Name,
+		Password: "test-password",
+	}
+	if err := mikrotik.User.Add(&u); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.User.SetPassword(u.ID, "new-password"); err != nil {
+		t.Error(err)
+	}
+
+	var active []*UserActive
+	if err := mikrotik.User.Active.Print(&active); err != nil {
+		t.Error(err)
+	}
+
+	for _, a := range active {
+		t.Logf("%+v", a)
+	}
+
+	if err := mikrotik.User.Remove(u.ID); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.User.Group.Remove(group.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScript(t *testing.T) {
+	s := Script{
+		Name:   "test-script",
+		Source: `:log info "test-script"`,
+	}
+	if err := mikrotik.System.Script.Add(&s); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.System.Script.Run(s.Name); err != nil {
+		t.Error(err)
+	}
+
+	sched := Scheduler{
+		Name:     "test-scheduler",
+		Interval: time.Hour,
+		OnEvent:  s.Name,
+	}
+	if err := mikrotik.System.Scheduler.Add(&sched); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.System.Scheduler.Remove(sched.ID); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.System.Script.Remove(s.ID); err != nil {
+		t.Error(err)
+	}
+
+	out, err := mikrotik.Execute(`:put [/system/identity/get name]`)
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(out)
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, tc := range []struct {
+		dur time.Duration
+		out string
+	}{
+		{dur: 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second, out: "1w2d3h4m5s"},
+		{dur: time.Hour, out: "1h"},
+		{dur: 1500 * time.Millisecond, out: "1s500ms"},
+		{dur: 0, out: "0s"},
+	} {
+		out := FormatDuration(tc.dur)
+		if out != tc.out {
+			t.Errorf("%s: expected %q, got %q", tc.dur, tc.out, out)
+		}
+
+		dur, err := ParseDuration(out)
+		if err != nil || dur != tc.dur {
+			t.Errorf("%q: parsed back to %s, %v", out, dur, err)
+		}
+	}
+}
+
+func TestPackageUpdate(t *testing.T) {
+	update, err := mikrotik.System.Package.Update.CheckForUpdates()
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Logf("%+v", update)
+
+	var packages []*Package
+	if err := mikrotik.System.Package.List(&packages); err != nil {
+		t.Error(err)
+	}
+
+	for _, p := range packages {
+		t.Logf("%+v", p)
+	}
+}
+
+func TestSystemClockHealthLicense(t *testing.T) {
+	now, err := mikrotik.System.Clock.Time()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(now)
+
+	health, err := mikrotik.System.Health.Get()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", health)
+
+	license, err := mikrotik.System.License.Get()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", license)
+}
+
+func TestResource(t *testing.T) {
+	res, err := mikrotik.System.Resource.Get()
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	uptime, err := res.UptimeDuration()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Logf("uptime: %s memory: %.1f%% hdd: %.1f%%", uptime, res.MemoryUsed(), res.HddUsed())
+
+	cpus, err := mikrotik.System.Resource.CPU()
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, cpu := range cpus {
+		t.Logf("%+v", cpu)
+	}
+
+	rb, err := mikrotik.System.Routerboard.Get()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", rb)
+}
+
+func TestLog(t *testing.T) {
+	var entries []*LogEntry
+	if err := mikrotik.Log.List(&entries); err != nil {
+		t.Error(err)
+	}
+
+	for _, e := range entries {
+		t.Logf("%+v", e)
+	}
+
+	action := LoggingAction{
+		Name:       "testremote",
+		Target:     LoggingTargetRemote,
+		Remote:     "10.0.0.1",
+		RemotePort: 514,
+	}
+	if err := mikrotik.System.Logging.Action.Add(&action); err != nil {
+		t.Error(err)
+	}
+
+	rule := LoggingRule{
+		Topics: []string{"system", "info"},
+		Action: action.Name,
+	}
+	if err := mikrotik.System.Logging.Add(&rule); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.System.Logging.Remove(rule.ID); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.System.Logging.Action.Remove(action.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLogFollow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entries, err := mikrotik.Log.Follow(ctx)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := mikrotik.Execute(`:log info "test-follow"`); err != nil {
+		t.Error(err)
+	}
+
+	for e := range entries {
+		t.Logf("%+v", e)
+	}
+}
+
+func TestExport(t *testing.T) {
+	script, err := mikrotik.Export(ExportOptions{Path: "/ip/address", Terse: true})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Log(script)
+
+	if _, err := ParseConfig(script); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	a, err := ParseConfig(`# model = RB951Ui-2HnD
+/interface ethernet
+set [ find default-name=ether1 ] comment="uplink port"
+/ip address
+add address=10.0.0.1/24 interface=bridge1 \
+    network=10.0.0.0
+/system identity
+set name=router1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e := a["/interface/ethernet"][0]; e.Find != "[ find default-name=ether1 ]" || e.Params["comment"] != "uplink port" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	if e := a["/ip/address"][0]; e.Params["network"] != "10.0.0.0" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	b, err := ParseConfig(`/interface ethernet set [ find default-name=ether1 ] comment="uplink port"
+/ip address add address=10.0.0.2/24 interface=bridge1 network=10.0.0.0
+/system identity set name=router2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := DiffConfig(a, b)
+	if len(changes) != 3 {
+		t.Errorf("expected 3 changes, got %v", changes)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	if err := mikrotik.System.Backup.Save("test-backup", "test-password", BackupEncryptionAESSHA256); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var buf bytes.Buffer
+	if err := mikrotik.System.Backup.Download("test-backup", &buf); err != nil {
+		t.Error(err)
+	}
+
+	if buf.Len() == 0 {
+		t.Error("backup is empty")
+	}
+
+	if err := mikrotik.File.RemoveByName("test-backup.backup"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFile(t *testing.T) {
+	if err := mikrotik.File.Write("test-file.txt", strings.NewReader("test contents")); err != nil {
+		t.Error(err)
+	}
+
+	var list []*File
+	if err := mikrotik.File.List(&list); err != nil {
+		t.Error(err)
+	}
+
+	for _, f := range list {
+		t.Logf("%+v", f)
+	}
+
+	var buf bytes.Buffer
+	if err := mikrotik.File.Read("test-file.txt", &buf); err != nil {
+		t.Error(err)
+	}
+
+	if buf.String() != "test contents" {
+		t.Errorf("unexpected contents %q", buf.String())
+	}
+
+	if err := mikrotik.File.RemoveByName("test-file.txt"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	ca := Certificate{
+		Name:       "test-ca",
+		CommonName: "test-ca",
+		KeyUsage:   []string{CertificateKeyUsageKeyCertSign, CertificateKeyUsageCRLSign},
+		DaysValid:  365,
+	}
+	if err := mikrotik.Certificate.Add(&ca); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.Certificate.Sign(ca.ID, ""); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.Certificate.SetTrusted(ca.ID, true); err != nil {
+		t.Error(err)
+	}
+
+	var list []*Certificate
+	if err := mikrotik.Certificate.List(&list); err != nil {
+		t.Error(err)
+	}
+
+	for _, c := range list {
+		t.Logf("%+v", c)
+	}
+
+	if err := mikrotik.Certificate.Remove(ca.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMonitorTraffic(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	samples, err := mikrotik.Interface.MonitorTraffic(ctx, "ether1")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var n int
+	for sample := range samples {
+		t.Logf("%+v", sample)
+		n++
+	}
+
+	if n == 0 {
+		t.Error("traffic samples not received")
+	}
+}
+
+func TestEthernet(t *testing.T) {
+	var list []*Ethernet
+	if err := mikrotik.Interface.Ethernet.List(&list); err != nil {
+		t.Error(err)
+	}
+
+	for _, eth := range list {
+		t.Logf("%+v", eth)
+	}
+
+	mon, err := mikrotik.Interface.Ethernet.Monitor("ether1")
+	if err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", mon)
+
+	poe, err := mikrotik.Interface.Ethernet.PoEMonitor("ether2")
+	if err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", poe)
+}
+
+func TestEthernetSwitch(t *testing.T) {
+	var ports []*SwitchPort
+	if err := mikrotik.Interface.Ethernet.Switch.Port.List(&ports); err != nil {
+		t.Error(err)
+	}
+
+	for _, p := range ports {
+		t.Logf("%+v", p)
+	}
+
+	vlan := SwitchVLAN{
+		Switch: "switch1",
+		VlanID: 100,
+		Ports:  []string{"ether2", "ether3", "switch1-cpu"},
+	}
+	if err := mikrotik.Interface.Ethernet.Switch.VLAN.Add(&vlan); err != nil {
+		t.Error(err)
+	}
+
+	if err := mikrotik.Interface.Ethernet.Switch.VLAN.Remove(vlan.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWirelessRegistrationTable(t *testing.T) {
+	var list []*WirelessRegistration
+	if err := mikrotik.Interface.Wireless.RegistrationTable.List(&list); err != nil {
+		t.Error(err)
+	}
+
+	for _, reg := range list {
+		t.Logf("%+v signal: %d", reg, reg.Signal())
+	}
+
+	var rules []*WirelessAccessRule
+	if err := mikrotik.Interface.Wireless.AccessList.List(&rules); err != nil {
+		t.Error(err)
+	}
+
+	for _, rule := range rules {
+		t.Logf("%+v", rule)
+	}
+}
+
+func TestWirelessScanStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	aps, err := mikrotik.Interface.Wireless.ScanStream(ctx, "wlan1")
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for ap := range aps {
+		t.Logf("%+v", ap)
+	}
+}
+
+func TestWirelessClients(t *testing.T) {
+	clients, err := mikrotik.Interface.WirelessClients()
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, c := range clients {
+		t.Logf("%+v", c)
+	}
+}
+
+func TestCAPsMAN(t *testing.T) {
+	var manager *CAPsMANManager
+	if err := mikrotik.CAPsMAN.Manager.Get(&manager); err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", manager)
+
+	var caps []*RemoteCap
+	if err := mikrotik.CAPsMAN.RemoteCap.List(&caps); err != nil {
+		t.Error(err)
+	}
+
+	for _, c := range caps {
+		t.Logf("%+v", c)
+	}
+
+	var clients []*CAPsMANRegistration
+	if err := mikrotik.CAPsMAN.RegistrationTable.List(&clients); err != nil {
+		t.Error(err)
+	}
+
+	for _, c := range clients {
+		t.Logf("%+v", c)
+	}
+}
+
+func TestLte(t *testing.T) {
+	var info LteInfo
+	if err := mikrotik.Interface.Lte.InfoOnce("lte1", &info); err != nil {
+		t.Error(err)
+	}
+	t.Logf("%+v", info)
+
+	var apns []*LteAPN
+	if err := mikrotik.Interface.Lte.APN.List(&apns); err != nil {
+		t.Error(err)
+	}
+
+	for _, apn := range apns {
+		t.Logf("%+v", apn)
+	}
+
+	var inbox []*SMS
+	if err := mikrotik.Tool.SMS.Inbox.List(&inbox); err != nil {
+		t.Error(err)
+	}
+
+	for _, sms := range inbox {
+		t.Logf("%+v", sms)
+	}
+
+	balance, err := mikrotik.Interface.Lte.USSD("lte1", "*100#")
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(balance)
+}
+
+func TestLteInfoEmptySignal(t *testing.T) {
+	var info *LteInfo
+	err := ValuesFrom(map[string]string{"registration-status": "searching", "rssi": "", "rsrp": "", "sinr": ""}).To(&info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.RegistrationStatus != "searching" || info.Rssi != 0 {
+		t.Errorf("unexpected info %+v", info)
+	}
+}
+
+func TestLteMonitor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := mikrotik.Interface.Lte.Monitor(ctx, "lte1", time.Second)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for event := range events {
+		t.Logf("%s %+v", event.Type, event.Info)
+	}
+}
+
+func TestTraceroute(t *testing.T) {
+	tr := mikrotik.Traceroute("8.8.8.8")
+	tr.MaxHops = 10
+
+	hops, err := tr.Start()
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, hop := range hops {
+		t.Logf("%+v", hop)
+	}
+}
+
+func TestPingStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ping := mikrotik.Ping("8.8.8.8", 5)
+	ping.Interval = 500 * time.Millisecond
+	stats, err := ping.Stream(ctx, func(resp *PingResponse) {
+		t.Logf("%+v", resp)
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	t.Logf("%+v", stats)
+}
+
+func TestPingStats(t *testing.T) {
+	stats := NewPingStats([]*PingResponse{
+		{Seq: 0, Sent: 1, Received: 1, Time: 10 * time.Millisecond},
+		{Seq: 1, Sent: 2, Received: 2, Time: 20 * time.Millisecond},
+		{Seq: 2, Sent: 3, Received: 2, Status: "timeout"},
+		{Seq: 3, Sent: 4, Received: 3, Time: 30 * time.Millisecond},
+	})
+
+	expected := PingStats{
+		Sent:     4,
+		Received: 3,
+		Loss:     25,
+		Min:      10 * time.Millisecond,
+		Avg:      20 * time.Millisecond,
+		Max:      30 * time.Millisecond,
+		Jitter:   10 * time.Millisecond,
+	}
+	if *stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	if stats := NewPingStats(nil); stats.Received != 0 || stats.Loss != 0 {
+		t.Errorf("unexpected stats of empty replies %+v", stats)
+	}
+}
+
+func TestBandwidthTest(t *testing.T) {
+	opts := BandwidthTest{
+		Address:   "10.0.0.1",
+		Direction: BandwidthTestBoth,
+		Protocol:  "tcp",
+		Duration:  5 * time.Second,
+		User:      "admin",
+	}
+
+	result, err := mikrotik.BandwidthTest(context.Background(), opts, func(sample *BandwidthTestSample) {
+		t.Logf("%+v", sample)
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	t.Logf("%+v", result)
+}
+
+func TestTorch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	snapshots, err := mikrotik.Tool.Torch.Start(ctx, Torch{
+		Interface:  "ether1",
+		SrcAddress: "0.0.0.0/0",
+		DstAddress: "0.0.0.0/0",
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for flows := range snapshots {
+		for _, flow := range flows {
+			t.Logf("%+v", flow)
+		}
+	}
+}
+
+func TestTZSP(t *testing.T) {
+	frame := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 1, 2, 3, 4, 5, 0x08, 0x06}
+	datagram := append([]byte{1, 0, 0, TZSPEthernet, 0, 10, 2, 0xaa, 0xbb, tzspTagEnd}, frame...)
+
+	p, err := DecodeTZSP(datagram)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(p.Data, frame) {
+		t.Errorf("unexpected frame %x", p.Data)
+	}
+
+	var buf bytes.Buffer
+	pw, err := NewPcapWriter(&buf, PcapLinkTypeEthernet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pw.WritePacket(p); err != nil {
+		t.Error(err)
+	}
+
+	if buf.Len() != 24+16+len(frame) {
+		t.Errorf("unexpected pcap size %d", buf.Len())
+	}
+
+	if lt := PcapLinkType(TZSP80211); lt != PcapLinkType80211 {
+		t.Errorf("unexpected link type of 802.11 %d", lt)
+	}
+}
+
+func TestNetwatch(t *testing.T) {
+	nw := Netwatch{
+		Host:     "8.8.8.8",
+		Interval: 10 * time.Second,
+		Comment:  "test-netwatch",
+	}
+	if err := mikrotik.Tool.Netwatch.Add(&nw); err != nil {
+		t.Error(err)
+	}
+
+	var list []*Netwatch
+	if err := mikrotik.Tool.Netwatch.List(&list); err != nil {
+		t.Error(err)
+	}
+
+	for _, item := range list {
+		t.Logf("%+v", item)
+	}
+
+	if err := mikrotik.Tool.Netwatch.Remove(nw.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	result, err := mikrotik.Tool.Fetch.Run(Fetch{
+		URL:    "http://example.com/",
+		Output: FetchOutputUser,
+	})
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Logf("%s %d", result.Status, len(result.Data))
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		dur time.Duration
+		err bool
+	}{
+		{in: "1w2d3h4m5s", dur: 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second},
+		{in: "1d12:30:00", dur: 36*time.Hour + 30*time.Minute},
+		{in: "00:00:01.5", dur: 1500 * time.Millisecond},
+		{in: "12ms", dur: 12 * time.Millisecond},
+		{in: "3s270ms", dur: 3270 * time.Millisecond},
+		{in: "never", dur: 0},
+		{in: "timeout", dur: 0},
+		{in: "", dur: 0},
+		{in: "abc", err: true},
+		{in: "xd3h", err: true},
+		{in: "1:xx:00", err: true},
+	} {
+		dur, err := ParseDuration(tc.in)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", tc.in, dur)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+
+		if dur != tc.dur {
+			t.Errorf("%q: expected %s, got %s", tc.in, tc.dur, dur)
+		}
+	}
+}
+
+func TestTracerouteTimeoutHop(t *testing.T) {
+	var hops []*TracerouteHop
+	for _, m := range []map[string]string{
+		{"address": "10.0.0.1", "loss": "0%", "sent": "3", "last": "1.2ms", "avg": "1.1ms", "best": "0.9ms", "worst": "1.2ms", "std-dev": "0.1ms"},
+		{"address": "", "loss": "100%", "sent": "3", "last": "timeout", "avg": "", "best": "", "worst": "", "std-dev": ""},
+	} {
+		if err := ValuesFrom(m).To(&hops); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(hops) != 2 {
+		t.Fatalf("expected 2 hops, got %d", len(hops))
+	}
+
+	if hop := hops[1]; hop.Loss != 100 || hop.Sent != 3 || hop.Last != 0 || hop.Avg != 0 {
+		t.Errorf("unexpected timed out hop %+v", hop)
+	}
+}
@@ -3,6 +3,8 @@ package mikrotik
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,15 +16,17 @@ import (
 
 type Ping struct {
 	mikrotik *Mikrotik
-	Address  string
-
-	Count        int
-	Interface    string
-	Interval     int
-	RoutingTable string
-	Size         int
-	SrcAddress   string
-	TTL          int `mikrotik:"ttl"`
+	Address  string // IPv4 or IPv6 address, link-local IPv6 address should be with %interface
+
+	Count         int
+	Interface     string
+	Interval      time.Duration
+	RoutingTable  string
+	Size          int
+	SrcAddress    string
+	TTL           int  `mikrotik:"ttl"`
+	ArpPing       bool `mikrotik:"arp-ping"` // requires Interface
+	DoNotFragment bool `mikrotik:"-"`
 }
 
 type PingResponse struct {
@@ -44,6 +48,239 @@ type PingResponse struct {
 	MaxRTT time.Duration `mikrotik:"max-rtt"`
 }
 
+// PingStats is aggregated statistics of ping replies
+type PingStats struct {
+	Sent     int
+	Received int
+	Loss     float64 // %
+
+	Min    time.Duration
+	Avg    time.Duration
+	Max    time.Duration
+	Jitter time.Duration // mean difference of consecutive round trip times
+}
+
+// NewPingStats calculate statistics from ping replies
+func NewPingStats(responses []*PingResponse) *PingStats {
+	stats := new(PingStats)
+
+	var sum, jitterSum, prev time.Duration
+	var jitterCount int
+	for _, resp := range responses {
+		if resp.Sent > stats.Sent {
+			stats.Sent = resp.Sent
+		}
+
+		if resp.Status != "" {
+			continue
+		}
+
+		stats.Received++
+		sum += resp.Time
+		if stats.Received == 1 || resp.Time < stats.Min {
+			stats.Min = resp.Time
+		}
+		if resp.Time > stats.Max {
+			stats.Max = resp.Time
+		}
+
+		if stats.Received > 1 {
+			diff := resp.Time - prev
+			if diff < 0 {
+				diff = -diff
+			}
+			jitterSum += diff
+			jitterCount++
+		}
+		prev = resp.Time
+	}
+
+	if stats.Sent < len(responses) {
+		stats.Sent = len(responses)
+	}
+
+	if stats.Received > 0 {
+		stats.Avg = sum / time.Duration(stats.Received)
+	}
+
+	if jitterCount > 0 {
+		stats.Jitter = jitterSum / time.Duration(jitterCount)
+	}
+
+	if stats.Sent > 0 {
+		stats.Loss = float64(stats.Sent-stats.Received) / float64(stats.Sent) * 100
+	}
+
+	return stats
+}
+
+// Traceroute options of /tool/traceroute
+type Traceroute struct {
+	mikrotik *Mikrotik
+	Address  string
+
+	Count        int
+	MaxHops      int    `mikrotik:"max-hops"`
+	SrcAddress   string `mikrotik:"src-address"`
+	Interface    string
+	Protocol     string // icmp, udp
+	Port         int
+	RoutingTable string        `mikrotik:"routing-table"`
+	UseDNS       bool          `mikrotik:"use-dns"`
+	Timeout      time.Duration `mikrotik:"timeout"`
+	Size         int
+}
+
+// TracerouteHop is one hop of traceroute round
+type TracerouteHop struct {
+	Address string
+	Loss    int `trim:"%"`
+	Sent    int
+	Last    time.Duration
+	Avg     time.Duration
+	Best    time.Duration
+	Worst   time.Duration
+	StdDev  time.Duration `mikrotik:"std-dev"`
+	Status  string
+}
+
+// BandwidthTest options of /tool/bandwidth-test
+type BandwidthTest struct {
+	Address         string
+	Direction       string // receive, transmit, both
+	Protocol        string // tcp, udp
+	LocalTxSpeed    string `mikrotik:"local-tx-speed"`  // bits per second, like 10M
+	RemoteTxSpeed   string `mikrotik:"remote-tx-speed"` // bits per second, like 10M
+	LocalUDPTxSize  int    `mikrotik:"local-udp-tx-size"`
+	RemoteUDPTxSize int    `mikrotik:"remote-udp-tx-size"`
+	Duration        time.Duration
+	ConnectionCount int `mikrotik:"connection-count"`
+	RandomData      bool
+	User            string
+	Password        string
+}
+
+const (
+	BandwidthTestReceive  = "receive"
+	BandwidthTestTransmit = "transmit"
+	BandwidthTestBoth     = "both"
+)
+
+// BandwidthTestSample is progress of bandwidth test, rates are in bits per second
+type BandwidthTestSample struct {
+	Status   string
+	Duration time.Duration
+
+	TxCurrent         int `mikrotik:"tx-current"`
+	Tx10SecondAverage int `mikrotik:"tx-10-second-average"`
+	TxTotalAverage    int `mikrotik:"tx-total-average"`
+	RxCurrent         int `mikrotik:"rx-current"`
+	Rx10SecondAverage int `mikrotik:"rx-10-second-average"`
+	RxTotalAverage    int `mikrotik:"rx-total-average"`
+
+	LostPackets     int `mikrotik:"lost-packets"`
+	ConnectionCount int `mikrotik:"connection-count"`
+	LocalCPULoad    int `mikrotik:"local-cpu-load" trim:"%"`
+	RemoteCPULoad   int `mikrotik:"remote-cpu-load" trim:"%"`
+}
+
+// Torch options of /tool/torch, set address to 0.0.0.0/0 and port or protocol to any
+// to get traffic by each address, port or protocol
+type Torch struct {
+	Interface   string
+	SrcAddress  string `mikrotik:"src-address"`
+	DstAddress  string `mikrotik:"dst-address"`
+	SrcAddress6 string `mikrotik:"src-address6"`
+	DstAddress6 string `mikrotik:"dst-address6"`
+	Port        string
+	IPProtocol  string `mikrotik:"ip-protocol"`
+	MacProtocol string `mikrotik:"mac-protocol"`
+	VlanID      string `mikrotik:"vlan-id"`
+}
+
+// TorchFlow is traffic of one flow, rates are in bits per second
+type TorchFlow struct {
+	SrcAddress  string `mikrotik:"src-address"`
+	DstAddress  string `mikrotik:"dst-address"`
+	IPProtocol  string `mikrotik:"ip-protocol"`
+	MacProtocol string `mikrotik:"mac-protocol"`
+	SrcPort     string `mikrotik:"src-port"`
+	DstPort     string `mikrotik:"dst-port"`
+	VlanID      string `mikrotik:"vlan-id"`
+	Tx          int
+	Rx          int
+	TxPackets   int `mikrotik:"tx-packets"`
+	RxPackets   int `mikrotik:"rx-packets"`
+}
+
+// SnifferSettings /tool/sniffer
+type SnifferSettings struct {
+	FileName    string `mikrotik:"file-name"`
+	FileLimit   string `mikrotik:"file-limit"`
+	MemoryLimit string `mikrotik:"memory-limit"`
+
+	FilterInterface  []string `mikrotik:"filter-interface"`
+	FilterIPAddress  []string `mikrotik:"filter-ip-address"`
+	FilterMacAddress []string `mikrotik:"filter-mac-address"`
+	FilterPort       []string `mikrotik:"filter-port"`
+	FilterIPProtocol []string `mikrotik:"filter-ip-protocol"`
+	FilterDirection  string   `mikrotik:"filter-direction"`
+	FilterStream     bool     `mikrotik:"filter-stream"`
+
+	StreamingEnabled bool   `mikrotik:"streaming-enabled"`
+	StreamingServer  string `mikrotik:"streaming-server"` // address:port of TZSP receiver
+
+	Running bool `mikrotik:"running,ro"`
+}
+
+// Netwatch /tool/netwatch
+type Netwatch struct {
+	ID         string `mikrotik:".id"`
+	Host       string
+	Type       string // simple, icmp, tcp-conn, http-get; RouterOS v7
+	Interval   time.Duration
+	Timeout    time.Duration
+	UpScript   string `mikrotik:"up-script"`
+	DownScript string `mikrotik:"down-script"`
+	Status     string `mikrotik:"status,ro"` // up, down, unknown
+	Since      string `mikrotik:"since,ro"`
+	Disabled   bool
+	Comment    string
+}
+
+// Fetch options of /tool/fetch
+type Fetch struct {
+	URL              string `mikrotik:"url"`
+	Mode             string // http, https, ftp, tftp
+	Address          string
+	Port             int
+	SrcPath          string   `mikrotik:"src-path"`
+	DstPath          string   `mikrotik:"dst-path"`
+	User             string   `mikrotik:"user"`
+	Password         string   `mikrotik:"password"`
+	Upload           bool     `mikrotik:"upload"`
+	Output           string   `mikrotik:"output"` // none, file, user
+	HTTPMethod       string   `mikrotik:"http-method"`
+	HTTPData         string   `mikrotik:"http-data"`
+	HTTPHeaderField  []string `mikrotik:"http-header-field"`
+	CheckCertificate string   `mikrotik:"check-certificate"`
+}
+
+const (
+	FetchOutputNone = "none"
+	FetchOutputFile = "file"
+	FetchOutputUser = "user"
+)
+
+// FetchResult is last status of /tool/fetch
+type FetchResult struct {
+	Status     string
+	Downloaded int
+	Total      int
+	Duration   time.Duration
+	Data       string
+}
+
 // IPAddress /ip/address
 type IPAddress struct {
 	ID string `mikrotik:".id"`
@@ -218,6 +455,26 @@ type Interface struct {
 	Comment  string
 }
 
+// TrafficSample from /interface/monitor-traffic
+type TrafficSample struct {
+	Name string
+
+	RxPacketsPerSecond   int `mikrotik:"rx-packets-per-second"`
+	RxBitsPerSecond      int `mikrotik:"rx-bits-per-second"`
+	RxDropsPerSecond     int `mikrotik:"rx-drops-per-second"`
+	RxErrorsPerSecond    int `mikrotik:"rx-errors-per-second"`
+	FpRxPacketsPerSecond int `mikrotik:"fp-rx-packets-per-second"`
+	FpRxBitsPerSecond    int `mikrotik:"fp-rx-bits-per-second"`
+
+	TxPacketsPerSecond    int `mikrotik:"tx-packets-per-second"`
+	TxBitsPerSecond       int `mikrotik:"tx-bits-per-second"`
+	TxDropsPerSecond      int `mikrotik:"tx-drops-per-second"`
+	TxQueueDropsPerSecond int `mikrotik:"tx-queue-drops-per-second"`
+	TxErrorsPerSecond     int `mikrotik:"tx-errors-per-second"`
+	FpTxPacketsPerSecond  int `mikrotik:"fp-tx-packets-per-second"`
+	FpTxBitsPerSecond     int `mikrotik:"fp-tx-bits-per-second"`
+}
+
 type WirelessInterface struct {
 	ID                       string `mikrotik:".id"`
 	DefaultName              string `mikrotik:"default-name"`
@@ -315,6 +572,22 @@ type WirelessAP struct {
 	RadioName       string `mikrotik:"radio-name"`
 	RouterOSVersion string `mikrotik:"routeros-version"`
 	Section         int
+
+	// SignalHistory is filled only by ScanStream
+	SignalHistory []int `mikrotik:"-"`
+}
+
+// FrequencyUsage from /interface/wireless/frequency-monitor
+type FrequencyUsage struct {
+	Freq int
+	Use  float64 // %
+	NF   int     `mikrotik:"nf"`
+}
+
+// SpectralSample from /interface/wireless/spectral-scan
+type SpectralSample struct {
+	Freq float64
+	Dbm  float64
 }
 
 type WirelessSecurityProfile struct {
@@ -351,6 +624,167 @@ type WirelessSecurityProfile struct {
 	ManagementProtectionKey string `mikrotik:"management-protection-key"`
 }
 
+// WirelessRegistration /interface/wireless/registration-table
+type WirelessRegistration struct {
+	ID         string `mikrotik:".id"`
+	Interface  string
+	MacAddress string `mikrotik:"mac-address"`
+	RadioName  string `mikrotik:"radio-name"`
+	AP         bool   `mikrotik:"ap"`
+	WDS        bool   `mikrotik:"wds"`
+	Bridge     bool
+
+	RxRate         string `mikrotik:"rx-rate"`
+	TxRate         string `mikrotik:"tx-rate"`
+	Packets        string
+	Bytes          string
+	SignalStrength string `mikrotik:"signal-strength"` // -62@HT20-7
+	SignalToNoise  int    `mikrotik:"signal-to-noise"`
+	TxCCQ          int    `mikrotik:"tx-ccq"`
+	LastIP         string `mikrotik:"last-ip"`
+
+	Uptime       time.Duration
+	LastActivity time.Duration `mikrotik:"last-activity"`
+}
+
+// Signal returns signal strength in dBm without rate
+func (r WirelessRegistration) Signal() int {
+	signal := r.SignalStrength
+	if i := strings.IndexByte(signal, '@'); i >= 0 {
+		signal = signal[:i]
+	}
+
+	n, _ := strconv.Atoi(strings.TrimSuffix(signal, "dBm"))
+	return n
+}
+
+// WirelessAccessRule /interface/wireless/access-list
+type WirelessAccessRule struct {
+	ID                  string `mikrotik:".id"`
+	Interface           string
+	MacAddress          string `mikrotik:"mac-address"`
+	SignalRange         string `mikrotik:"signal-range"`
+	Authentication      string // yes, no
+	Forwarding          string // yes, no
+	APTxLimit           int    `mikrotik:"ap-tx-limit"`
+	ClientTxLimit       int    `mikrotik:"client-tx-limit"`
+	PrivatePreSharedKey string `mikrotik:"private-pre-shared-key"`
+	Disabled            bool
+	Comment             string
+}
+
+// WirelessConnectRule /interface/wireless/connect-list
+type WirelessConnectRule struct {
+	ID               string `mikrotik:".id"`
+	Interface        string
+	MacAddress       string `mikrotik:"mac-address"`
+	SSID             string `mikrotik:"ssid"`
+	AreaPrefix       string `mikrotik:"area-prefix"`
+	SignalRange      string `mikrotik:"signal-range"`
+	SecurityProfile  string `mikrotik:"security-profile"`
+	WirelessProtocol string `mikrotik:"wireless-protocol"`
+	Connect          string // yes, no
+	Disabled         bool
+	Comment          string
+}
+
+// WiFiInterface /interface/wifi
+type WiFiInterface struct {
+	ID              string `mikrotik:".id"`
+	Name            string
+	DefaultName     string `mikrotik:"default-name,ro"`
+	MacAddress      string `mikrotik:"mac-address"`
+	MasterInterface string `mikrotik:"master-interface"`
+	Configuration   string
+	Security        string
+	Channel         string
+	Datapath        string
+	SSID            string `mikrotik:"configuration.ssid"`
+	Mode            string `mikrotik:"configuration.mode"`
+	Running         bool   `mikrotik:"running,ro"`
+	Disabled        bool
+	Comment         string
+}
+
+// WiFiConfiguration /interface/wifi/configuration
+type WiFiConfiguration struct {
+	ID       string `mikrotik:".id"`
+	Name     string
+	SSID     string `mikrotik:"ssid"`
+	Mode     string
+	Country  string
+	Security string
+	Channel  string
+	Datapath string
+	HideSSID bool `mikrotik:"hide-ssid"`
+	TxPower  int  `mikrotik:"tx-power"`
+	Comment  string
+}
+
+// WiFiSecurity /interface/wifi/security
+type WiFiSecurity struct {
+	ID                   string `mikrotik:".id"`
+	Name                 string
+	AuthenticationTypes  []string `mikrotik:"authentication-types"`
+	Passphrase           string
+	Encryption           []string
+	ManagementProtection string `mikrotik:"management-protection"`
+	WPS                  string `mikrotik:"wps"`
+	FT                   bool   `mikrotik:"ft"`
+	Comment              string
+}
+
+// WiFiChannel /interface/wifi/channel
+type WiFiChannel struct {
+	ID              string `mikrotik:".id"`
+	Name            string
+	Band            string
+	Width           string
+	Frequency       []string
+	SkipDFSChannels string `mikrotik:"skip-dfs-channels"`
+	Comment         string
+}
+
+// WiFiDatapath /interface/wifi/datapath
+type WiFiDatapath struct {
+	ID              string `mikrotik:".id"`
+	Name            string
+	Bridge          string
+	VlanID          int  `mikrotik:"vlan-id"`
+	ClientIsolation bool `mikrotik:"client-isolation"`
+	Comment         string
+}
+
+// WiFiRegistration /interface/wifi/registration-table
+type WiFiRegistration struct {
+	ID           string `mikrotik:".id"`
+	Interface    string
+	SSID         string `mikrotik:"ssid"`
+	MacAddress   string `mikrotik:"mac-address"`
+	AuthType     string `mikrotik:"auth-type"`
+	Band         string
+	Signal       int
+	RxRate       string `mikrotik:"rx-rate"`
+	TxRate       string `mikrotik:"tx-rate"`
+	Bytes        string
+	Packets      string
+	Uptime       time.Duration
+	LastActivity time.Duration `mikrotik:"last-activity"`
+}
+
+// WirelessClient is connected client independent of wireless package, returned by Interface.WirelessClients
+type WirelessClient struct {
+	// Package is path of wireless package, like /interface/wifi or /interface/wireless
+	Package    string
+	Interface  string
+	MacAddress string
+	SSID       string
+	Signal     int // dBm
+	RxRate     string
+	TxRate     string
+	Uptime     time.Duration
+}
+
 const (
 	WPA_PSK  = "wpa-psk"
 	WPA2_PSK = "wpa2-psk"
@@ -433,6 +867,385 @@ const (
 	PPPServiceSSTP  = "sstp"
 )
 
+// PPPActive /ppp/active
+type PPPActive struct {
+	ID            string `mikrotik:".id"`
+	Name          string
+	Service       string
+	CallerID      string `mikrotik:"caller-id"`
+	Address       string
+	Uptime        time.Duration
+	Encoding      string
+	SessionID     string `mikrotik:"session-id"`
+	LimitBytesIn  int    `mikrotik:"limit-bytes-in"`
+	LimitBytesOut int    `mikrotik:"limit-bytes-out"`
+	Radius        bool
+}
+
+// User /user
+type User struct {
+	ID           string `mikrotik:".id"`
+	Name         string
+	Group        string
+	Address      string
+	Password     string
+	LastLoggedIn string `mikrotik:"last-logged-in,ro"`
+	Disabled     bool
+	Comment      string
+}
+
+// UserGroup /user/group
+type UserGroup struct {
+	ID      string `mikrotik:".id"`
+	Name    string
+	Policy  []string
+	Skin    string
+	Comment string
+}
+
+const (
+	UserPolicyLocal     = "local"
+	UserPolicyTelnet    = "telnet"
+	UserPolicySSH       = "ssh"
+	UserPolicyFTP       = "ftp"
+	UserPolicyReboot    = "reboot"
+	UserPolicyRead      = "read"
+	UserPolicyWrite     = "write"
+	UserPolicyPolicy    = "policy"
+	UserPolicyTest      = "test"
+	UserPolicyWinbox    = "winbox"
+	UserPolicyPassword  = "password"
+	UserPolicyWeb       = "web"
+	UserPolicySniff     = "sniff"
+	UserPolicySensitive = "sensitive"
+	UserPolicyAPI       = "api"
+	UserPolicyRomon     = "romon"
+	UserPolicyRestAPI   = "rest-api"
+)
+
+// UserActive /user/active
+type UserActive struct {
+	ID      string `mikrotik:".id"`
+	When    string
+	Name    string
+	Address string
+	Via     string
+	Group   string
+	Radius  bool
+}
+
+// UserSSHKey /user/ssh-keys
+type UserSSHKey struct {
+	ID       string `mikrotik:".id"`
+	User     string
+	Bits     int
+	KeyOwner string `mikrotik:"key-owner"`
+}
+
+// Script /system/script
+type Script struct {
+	ID                     string `mikrotik:".id"`
+	Name                   string
+	Owner                  string `mikrotik:"owner,ro"`
+	Policy                 []string
+	DontRequirePermissions bool `mikrotik:"dont-require-permissions"`
+	Source                 string
+	RunCount               int    `mikrotik:"run-count,ro"`
+	LastStarted            string `mikrotik:"last-started,ro"`
+	Invalid                bool   `mikrotik:"invalid,ro"`
+	Comment                string
+}
+
+// ScriptJob /system/script/job
+type ScriptJob struct {
+	ID      string `mikrotik:".id"`
+	Script  string
+	Owner   string
+	Type    string
+	Started string
+	Parent  string
+}
+
+// Scheduler /system/scheduler
+type Scheduler struct {
+	ID        string `mikrotik:".id"`
+	Name      string
+	StartDate string `mikrotik:"start-date"`
+	StartTime string `mikrotik:"start-time"`
+	Interval  time.Duration
+	OnEvent   string `mikrotik:"on-event"`
+	Policy    []string
+	Owner     string `mikrotik:"owner,ro"`
+	RunCount  int    `mikrotik:"run-count,ro"`
+	NextRun   string `mikrotik:"next-run,ro"`
+	Disabled  bool
+	Comment   string
+}
+
+// ResetConfiguration options for /system/reset-configuration
+type ResetConfiguration struct {
+	KeepUsers     bool   `mikrotik:"keep-users"`
+	NoDefaults    bool   `mikrotik:"no-defaults"`
+	SkipBackup    bool   `mikrotik:"skip-backup"`
+	RunAfterReset string `mikrotik:"run-after-reset"`
+}
+
+// Package /system/package
+type Package struct {
+	ID        string `mikrotik:".id"`
+	Name      string
+	Version   string
+	BuildTime string `mikrotik:"build-time"`
+	Scheduled string
+	Disabled  bool
+}
+
+// PackageUpdate /system/package/update
+type PackageUpdate struct {
+	Channel          string
+	InstalledVersion string `mikrotik:"installed-version"`
+	LatestVersion    string `mikrotik:"latest-version"`
+	Status           string
+}
+
+const (
+	PackageChannelStable      = "stable"
+	PackageChannelLongTerm    = "long-term"
+	PackageChannelTesting     = "testing"
+	PackageChannelDevelopment = "development"
+)
+
+// LogEntry /log
+type LogEntry struct {
+	ID      string `mikrotik:".id"`
+	Time    string
+	Topics  []string
+	Message string
+}
+
+// LoggingRule /system/logging
+type LoggingRule struct {
+	ID       string `mikrotik:".id"`
+	Topics   []string
+	Action   string
+	Prefix   string
+	Invalid  bool `mikrotik:"invalid,ro"`
+	Default  bool `mikrotik:"default,ro"`
+	Disabled bool
+}
+
+// LoggingAction /system/logging/action
+type LoggingAction struct {
+	ID     string `mikrotik:".id"`
+	Name   string
+	Target string
+
+	MemoryLines      int  `mikrotik:"memory-lines"`
+	MemoryStopOnFull bool `mikrotik:"memory-stop-on-full"`
+
+	DiskFileName     string `mikrotik:"disk-file-name"`
+	DiskLinesPerFile int    `mikrotik:"disk-lines-per-file"`
+	DiskFileCount    int    `mikrotik:"disk-file-count"`
+	DiskStopOnFull   bool   `mikrotik:"disk-stop-on-full"`
+
+	Remote           string
+	RemotePort       int    `mikrotik:"remote-port"`
+	SrcAddress       string `mikrotik:"src-address"`
+	BsdSyslog        bool   `mikrotik:"bsd-syslog"`
+	SyslogFacility   string `mikrotik:"syslog-facility"`
+	SyslogSeverity   string `mikrotik:"syslog-severity"`
+	SyslogTimeFormat string `mikrotik:"syslog-time-format"`
+
+	Default bool `mikrotik:"default,ro"`
+}
+
+const (
+	LoggingTargetMemory = "memory"
+	LoggingTargetDisk   = "disk"
+	LoggingTargetEcho   = "echo"
+	LoggingTargetRemote = "remote"
+)
+
+// File /file
+type File struct {
+	ID           string `mikrotik:".id"`
+	Name         string
+	Type         string
+	Size         int
+	CreationTime string `mikrotik:"creation-time"`
+}
+
+const (
+	BackupEncryptionAESSHA256 = "aes-sha256"
+	BackupEncryptionRC4       = "rc4"
+)
+
+// Certificate /certificate
+type Certificate struct {
+	ID   string `mikrotik:".id"`
+	Name string
+
+	CommonName     string `mikrotik:"common-name"`
+	SubjectAltName string `mikrotik:"subject-alt-name"`
+	Country        string
+	State          string
+	Locality       string
+	Organization   string
+	Unit           string
+	KeySize        string   `mikrotik:"key-size"`
+	KeyUsage       []string `mikrotik:"key-usage"`
+	DaysValid      int      `mikrotik:"days-valid"`
+
+	Trusted      bool   `mikrotik:"trusted,ro"`
+	Fingerprint  string `mikrotik:"fingerprint,ro"`
+	Issuer       string `mikrotik:"issuer,ro"`
+	SerialNumber string `mikrotik:"serial-number,ro"`
+	InvalidAfter string `mikrotik:"invalid-after,ro"`
+	ExpiresAfter string `mikrotik:"expires-after,ro"`
+	PrivateKey   bool   `mikrotik:"private-key,ro"`
+	CA           string `mikrotik:"ca,ro"`        // name of CA which signed certificate
+	Authority    bool   `mikrotik:"authority,ro"` // certificate is CA
+	Expired      bool   `mikrotik:"expired,ro"`
+	Revoked      bool   `mikrotik:"revoked,ro"`
+}
+
+const (
+	CertificateKeyUsageDigitalSignature = "digital-signature"
+	CertificateKeyUsageKeyEncipherment  = "key-encipherment"
+	CertificateKeyUsageDataEncipherment = "data-encipherment"
+	CertificateKeyUsageKeyCertSign      = "key-cert-sign"
+	CertificateKeyUsageCRLSign          = "crl-sign"
+	CertificateKeyUsageTLSServer        = "tls-server"
+	CertificateKeyUsageTLSClient        = "tls-client"
+)
+
+const (
+	CertificateExportPEM    = "pem"
+	CertificateExportPKCS12 = "pkcs12"
+)
+
+// CAPsMANManager /caps-man/manager
+type CAPsMANManager struct {
+	Enabled                bool
+	Certificate            string
+	CACertificate          string `mikrotik:"ca-certificate"`
+	RequirePeerCertificate bool   `mikrotik:"require-peer-certificate"`
+	UpgradePolicy          string `mikrotik:"upgrade-policy"`
+	PackagePath            string `mikrotik:"package-path"`
+}
+
+// CAPsMANInterface /caps-man/interface
+type CAPsMANInterface struct {
+	ID              string `mikrotik:".id"`
+	Name            string
+	MacAddress      string `mikrotik:"mac-address"`
+	MasterInterface string `mikrotik:"master-interface"`
+	Configuration   string
+	RadioMac        string `mikrotik:"radio-mac,ro"`
+	CurrentChannel  string `mikrotik:"current-channel,ro"`
+	Running         bool   `mikrotik:"running,ro"`
+	Inactive        bool   `mikrotik:"inactive,ro"`
+	Disabled        bool
+	Comment         string
+}
+
+// CAPsMANConfiguration /caps-man/configuration
+type CAPsMANConfiguration struct {
+	ID       string `mikrotik:".id"`
+	Name     string
+	SSID     string `mikrotik:"ssid"`
+	Mode     string
+	Country  string
+	Channel  string
+	Datapath string
+	Security string
+	HideSSID bool `mikrotik:"hide-ssid"`
+	Comment  string
+}
+
+// CAPsMANChannel /caps-man/channel
+type CAPsMANChannel struct {
+	ID                  string `mikrotik:".id"`
+	Name                string
+	Band                string
+	Frequency           []string
+	ControlChannelWidth string `mikrotik:"control-channel-width"`
+	ExtensionChannel    string `mikrotik:"extension-channel"`
+	TxPower             int    `mikrotik:"tx-power"`
+	Comment             string
+}
+
+// CAPsMANDatapath /caps-man/datapath
+type CAPsMANDatapath struct {
+	ID                       string `mikrotik:".id"`
+	Name                     string
+	Bridge                   string
+	LocalForwarding          bool   `mikrotik:"local-forwarding"`
+	ClientToClientForwarding bool   `mikrotik:"client-to-client-forwarding"`
+	VlanMode                 string `mikrotik:"vlan-mode"`
+	VlanID                   int    `mikrotik:"vlan-id"`
+	Comment                  string
+}
+
+// CAPsMANSecurity /caps-man/security
+type CAPsMANSecurity struct {
+	ID                  string `mikrotik:".id"`
+	Name                string
+	AuthenticationTypes []string `mikrotik:"authentication-types"`
+	Encryption          []string
+	GroupEncryption     string `mikrotik:"group-encryption"`
+	Passphrase          string
+	Comment             string
+}
+
+// CAPsMANProvisioning /caps-man/provisioning
+type CAPsMANProvisioning struct {
+	ID                  string `mikrotik:".id"`
+	Action              string
+	MasterConfiguration string   `mikrotik:"master-configuration"`
+	SlaveConfigurations []string `mikrotik:"slave-configurations"`
+	NamePrefix          string   `mikrotik:"name-prefix"`
+	NameFormat          string   `mikrotik:"name-format"`
+	HwSupportedModes    []string `mikrotik:"hw-supported-modes"`
+	IdentityRegexp      string   `mikrotik:"identity-regexp"`
+	RadioMac            string   `mikrotik:"radio-mac"`
+	Disabled            bool
+	Comment             string
+}
+
+const (
+	CAPsMANProvisioningCreateDisabled       = "create-disabled"
+	CAPsMANProvisioningCreateEnabled        = "create-enabled"
+	CAPsMANProvisioningCreateDynamicEnabled = "create-dynamic-enabled"
+	CAPsMANProvisioningNone                 = "none"
+)
+
+// RemoteCap /caps-man/remote-cap
+type RemoteCap struct {
+	ID       string `mikrotik:".id"`
+	Address  string
+	Name     string
+	Identity string
+	Board    string
+	Version  string
+	State    string
+	Radios   int
+}
+
+// CAPsMANRegistration /caps-man/registration-table
+type CAPsMANRegistration struct {
+	ID         string `mikrotik:".id"`
+	Interface  string
+	SSID       string `mikrotik:"ssid"`
+	MacAddress string `mikrotik:"mac-address"`
+	RxSignal   int    `mikrotik:"rx-signal"`
+	RxRate     string `mikrotik:"rx-rate"`
+	TxRate     string `mikrotik:"tx-rate"`
+	Packets    string
+	Bytes      string
+	Uptime     time.Duration
+}
+
 // Routerboard - information from /system/routerboard/print
 type Routerboard struct {
 	Routerboard     bool
@@ -468,12 +1281,25 @@ type LteInfo struct {
 	UICC               string `mikrotik:"uicc"`
 	SubscriberNumber   string `mikrotik:"subscriber-number"`
 	Earfcn             string
-	Rssi               string // should be float
-	Rsrp               string // should be float
-	Rsrq               string // should be float
-	Sinr               string // should be float
+	Rssi               float64 `trim:"dBm "` // dBm
+	Rsrp               float64 `trim:"dBm "` // dBm
+	Rsrq               float64 `trim:"dB "`  // dB
+	Sinr               float64 `trim:"dB "`  // dB
 }
 
+// LteEvent is sent by Lte.Monitor, Prev is previous sample and it is nil for first sample
+type LteEvent struct {
+	Type string
+	Info *LteInfo
+	Prev *LteInfo
+}
+
+const (
+	LteEventSample       = "sample"
+	LteEventRegistration = "registration"
+	LteEventHandover     = "handover"
+)
+
 type LtePrint struct {
 	ID           string `mikrotik:".id"`
 	Name         string
@@ -484,7 +1310,105 @@ type LtePrint struct {
 	AllowRoaming bool   `mikrotik:"allow-roaming"`
 	NetworkMode  string `mikrotik:"network-mode"`
 	Running      bool
-	Disalbed     bool
+	Disabled     bool
+}
+
+// LteAPN /interface/lte/apn
+type LteAPN struct {
+	ID                   string `mikrotik:".id"`
+	Name                 string
+	APN                  string `mikrotik:"apn"`
+	User                 string
+	Password             string
+	Authentication       string
+	IPType               string `mikrotik:"ip-type"`
+	AddDefaultRoute      bool   `mikrotik:"add-default-route"`
+	DefaultRouteDistance int    `mikrotik:"default-route-distance"`
+	UsePeerDNS           bool   `mikrotik:"use-peer-dns"`
+	Default              bool   `mikrotik:"default,ro"`
+	Comment              string
+}
+
+// SMS /tool/sms/inbox
+type SMS struct {
+	ID        string `mikrotik:".id"`
+	Phone     string
+	Message   string
+	Timestamp string
+	Type      string
+}
+
+// Clock from /system/clock/print
+type Clock struct {
+	Time               string
+	Date               string
+	TimeZoneName       string `mikrotik:"time-zone-name"`
+	TimeZoneAutodetect bool   `mikrotik:"time-zone-autodetect"`
+	GMTOffset          string `mikrotik:"gmt-offset"`
+	DSTActive          bool   `mikrotik:"dst-active"`
+}
+
+// Location returns fixed zone by current gmt offset of router
+func (c Clock) Location() (*time.Location, error) {
+	offset := strings.TrimPrefix(c.GMTOffset, "+")
+	sign := 1
+	if strings.HasPrefix(offset, "-") {
+		sign = -1
+		offset = offset[1:]
+	}
+
+	var dur time.Duration
+	if offset != "" {
+		t, err := time.Parse("15:04", offset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gmt offset %q", c.GMTOffset)
+		}
+		dur = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	return time.FixedZone(c.TimeZoneName, sign*int(dur.Seconds())), nil
+}
+
+// ToTime parse date and time, both v6 (jan/02/2006) and v7 (2006-01-02) date formats are supported
+func (c Clock) ToTime() (time.Time, error) {
+	loc, err := c.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", c.Date+" "+c.Time, loc); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation("Jan/02/2006 15:04:05", strings.Title(c.Date)+" "+c.Time, loc)
+}
+
+// Health from /system/health/print, not all sensors are present on every device
+type Health struct {
+	Voltage          float64
+	Current          float64
+	Temperature      float64
+	CPUTemperature   float64 `mikrotik:"cpu-temperature"`
+	BoardTemperature float64 `mikrotik:"board-temperature1"`
+	PowerConsumption float64 `mikrotik:"power-consumption"`
+	Fan1Speed        int     `mikrotik:"fan1-speed"`
+	Fan2Speed        int     `mikrotik:"fan2-speed"`
+	PSU1State        string  `mikrotik:"psu1-state"`
+	PSU2State        string  `mikrotik:"psu2-state"`
+
+	// Sensors contains all raw values by sensor name
+	Sensors map[string]string `mikrotik:"-"`
+}
+
+// License from /system/license/print
+type License struct {
+	SoftwareID   string   `mikrotik:"software-id"`
+	SystemID     string   `mikrotik:"system-id"`
+	Level        string   `mikrotik:"level"`
+	NLevel       int      `mikrotik:"nlevel"`
+	UpgradableTo string   `mikrotik:"upgradable-to"`
+	DeadlineAt   string   `mikrotik:"deadline-at"`
+	Features     []string `mikrotik:"features"`
 }
 
 // Resource from `/system resource print`
@@ -507,7 +1431,171 @@ type Resource struct {
 	Platform             string `mikrotik:"platform"`
 }
 
+// MemoryUsed returns used memory in percents
+func (r Resource) MemoryUsed() float64 {
+	if r.TotalMemory == 0 {
+		return 0
+	}
+	return float64(r.TotalMemory-r.FreeMemory) / float64(r.TotalMemory) * 100
+}
+
+// HddUsed returns used disk space in percents
+func (r Resource) HddUsed() float64 {
+	if r.TotalHddSpace == 0 {
+		return 0
+	}
+	return float64(r.TotalHddSpace-r.FreeHddSpace) / float64(r.TotalHddSpace) * 100
+}
+
+// UptimeDuration returns parsed uptime
+func (r Resource) UptimeDuration() (time.Duration, error) {
+	return ParseDuration(r.Uptime)
+}
+
+// ResourceCPU from `/system resource cpu print`
+type ResourceCPU struct {
+	ID   string `mikrotik:".id"`
+	CPU  string `mikrotik:"cpu"`
+	Load int    `mikrotik:"load"` // %
+	IRQ  int    `mikrotik:"irq"`  // %
+	Disk int    `mikrotik:"disk"` // %
+}
+
+// Ethernet /interface/ethernet
 type Ethernet struct {
-	// TODO: add other fields
-	PoEOut string `mikrotik:"poe-out"` // auto-on, forced-on, off
+	ID          string `mikrotik:".id"`
+	Name        string
+	DefaultName string `mikrotik:"default-name,ro"`
+	MTU         int    `mikrotik:"mtu"`
+	L2MTU       int    `mikrotik:"l2mtu"`
+	MACAddress  string `mikrotik:"mac-address"`
+	Arp         string
+
+	// false AutoNegotiation and FullDuplex are not sent by Set, use Ethernet.SetLink to force speed and duplex
+	AutoNegotiation bool `mikrotik:"auto-negotiation"`
+	Advertise       []string
+	Speed           string
+	FullDuplex      bool   `mikrotik:"full-duplex"`
+	TxFlowControl   string `mikrotik:"tx-flow-control"` // on, off, auto
+	RxFlowControl   string `mikrotik:"rx-flow-control"` // on, off, auto
+
+	PoEOut      string `mikrotik:"poe-out"` // auto-on, forced-on, off
+	PoEPriority int    `mikrotik:"poe-priority"`
+
+	Running  bool `mikrotik:"running,ro"`
+	Slave    bool `mikrotik:"slave,ro"`
+	Disabled bool
+	Comment  string
+}
+
+const (
+	PoEOutAutoOn   = "auto-on"
+	PoEOutForcedOn = "forced-on"
+	PoEOutOff      = "off"
+)
+
+// EthernetMonitor from /interface/ethernet/monitor
+type EthernetMonitor struct {
+	Name                   string
+	Status                 string
+	AutoNegotiation        string `mikrotik:"auto-negotiation"`
+	Rate                   string
+	FullDuplex             bool     `mikrotik:"full-duplex"`
+	TxFlowControl          bool     `mikrotik:"tx-flow-control"`
+	RxFlowControl          bool     `mikrotik:"rx-flow-control"`
+	Advertising            []string `mikrotik:"advertising"`
+	LinkPartnerAdvertising []string `mikrotik:"link-partner-advertising"`
+
+	SfpModulePresent    bool    `mikrotik:"sfp-module-present"`
+	SfpRxLoss           bool    `mikrotik:"sfp-rx-loss"`
+	SfpTxFault          bool    `mikrotik:"sfp-tx-fault"`
+	SfpType             string  `mikrotik:"sfp-type"`
+	SfpVendorName       string  `mikrotik:"sfp-vendor-name"`
+	SfpVendorPartNumber string  `mikrotik:"sfp-vendor-part-number"`
+	SfpWavelength       string  `mikrotik:"sfp-wavelength"`
+	SfpTemperature      float64 `mikrotik:"sfp-temperature" trim:"C"`
+	SfpSupplyVoltage    float64 `mikrotik:"sfp-supply-voltage" trim:"V"`
+	SfpTxBiasCurrent    float64 `mikrotik:"sfp-tx-bias-current" trim:"mA"`
+	SfpTxPower          float64 `mikrotik:"sfp-tx-power" trim:"dBm"`
+	SfpRxPower          float64 `mikrotik:"sfp-rx-power" trim:"dBm"`
+}
+
+// EthernetSwitch /interface/ethernet/switch
+type EthernetSwitch struct {
+	ID           string `mikrotik:".id"`
+	Name         string
+	Type         string `mikrotik:"type,ro"`
+	MirrorSource string `mikrotik:"mirror-source"`
+	MirrorTarget string `mikrotik:"mirror-target"`
+}
+
+// SwitchPort /interface/ethernet/switch/port
+type SwitchPort struct {
+	ID            string `mikrotik:".id"`
+	Name          string `mikrotik:"name,ro"`
+	Switch        string `mikrotik:"switch,ro"`
+	VlanMode      string `mikrotik:"vlan-mode"`
+	VlanHeader    string `mikrotik:"vlan-header"`
+	DefaultVlanID string `mikrotik:"default-vlan-id"`
+	Running       bool   `mikrotik:"running,ro"`
+}
+
+const (
+	SwitchVlanModeDisabled = "disabled"
+	SwitchVlanModeFallback = "fallback"
+	SwitchVlanModeCheck    = "check"
+	SwitchVlanModeSecure   = "secure"
+)
+
+const (
+	SwitchVlanHeaderLeaveAsIs    = "leave-as-is"
+	SwitchVlanHeaderAlwaysStrip  = "always-strip"
+	SwitchVlanHeaderAddIfMissing = "add-if-missing"
+)
+
+// SwitchVLAN /interface/ethernet/switch/vlan
+type SwitchVLAN struct {
+	ID                  string `mikrotik:".id"`
+	Switch              string
+	VlanID              int `mikrotik:"vlan-id"`
+	Ports               []string
+	IndependentLearning bool `mikrotik:"independent-learning"`
+	Disabled            bool
+	Comment             string
+}
+
+// SwitchRule /interface/ethernet/switch/rule
+type SwitchRule struct {
+	ID     string `mikrotik:".id"`
+	Switch string
+	Ports  []string
+
+	SrcMacAddress string `mikrotik:"src-mac-address"`
+	DstMacAddress string `mikrotik:"dst-mac-address"`
+	MacProtocol   string `mikrotik:"mac-protocol"`
+	SrcAddress    string `mikrotik:"src-address"`
+	DstAddress    string `mikrotik:"dst-address"`
+	Protocol      string
+	SrcPort       int `mikrotik:"src-port"`
+	DstPort       int `mikrotik:"dst-port"`
+	VlanID        int `mikrotik:"vlan-id"`
+
+	NewDstPorts   []string `mikrotik:"new-dst-ports"`
+	NewVlanID     int      `mikrotik:"new-vlan-id"`
+	RedirectToCPU bool     `mikrotik:"redirect-to-cpu"`
+	CopyToCPU     bool     `mikrotik:"copy-to-cpu"`
+	MirrorPorts   []string `mikrotik:"mirror-ports"`
+
+	Disabled bool
+	Comment  string
+}
+
+// PoEMonitor from /interface/ethernet/poe/monitor
+type PoEMonitor struct {
+	Name          string
+	PoEOut        string  `mikrotik:"poe-out"`
+	PoEOutStatus  string  `mikrotik:"poe-out-status"`
+	PoEOutVoltage float64 `mikrotik:"poe-out-voltage" trim:"V"`
+	PoEOutCurrent float64 `mikrotik:"poe-out-current" trim:"mA"`
+	PoEOutPower   float64 `mikrotik:"poe-out-power" trim:"W"`
 }
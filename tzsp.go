@@ -0,0 +1,191 @@
+package mikrotik
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// TZSPPort is default port of TZSP streaming
+const TZSPPort = 37008
+
+const (
+	tzspTagPadding = 0
+	tzspTagEnd     = 1
+)
+
+// TZSP encapsulated protocols
+const (
+	TZSPEthernet = 1
+	TZSP80211    = 18
+)
+
+// TZSPPacket is packet received from sniffer streaming
+type TZSPPacket struct {
+	Time     time.Time
+	Protocol uint16
+	Data     []byte
+}
+
+// DecodeTZSP decode TZSP datagram, tagged fields are skipped
+func DecodeTZSP(b []byte) (*TZSPPacket, error) {
+	if len(b) < 4 {
+		return nil, errors.New("tzsp: packet is too short")
+	}
+
+	if b[0] != 1 {
+		return nil, errors.New("tzsp: unsupported version")
+	}
+
+	p := &TZSPPacket{Time: time.Now(), Protocol: binary.BigEndian.Uint16(b[2:4])}
+
+	for i := 4; i < len(b); {
+		switch b[i] {
+		case tzspTagPadding:
+			i++
+		case tzspTagEnd:
+			p.Data = b[i+1:]
+			return p, nil
+		default:
+			if i+1 >= len(b) {
+				return nil, errors.New("tzsp: invalid tag")
+			}
+			i += 2 + int(b[i+1])
+		}
+	}
+
+	return nil, errors.New("tzsp: end tag not found")
+}
+
+// TZSPReceiver receive packets streamed by /tool/sniffer
+type TZSPReceiver struct {
+	conn *net.UDPConn
+	buf  []byte
+}
+
+// ListenTZSP listen udp address for TZSP stream, like :37008
+func ListenTZSP(addr string) (*TZSPReceiver, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TZSPReceiver{conn: conn, buf: make([]byte, 65535)}, nil
+}
+
+// ReadPacket wait for next packet
+func (r *TZSPReceiver) ReadPacket() (*TZSPPacket, error) {
+	b, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := DecodeTZSP(b)
+	if err != nil {
+		return nil, err
+	}
+
+	// buffer is reused by next read
+	p.Data = append([]byte(nil), p.Data...)
+
+	return p, nil
+}
+
+// read wait for next datagram, returned slice is valid until next read
+func (r *TZSPReceiver) read() ([]byte, error) {
+	n, _, err := r.conn.ReadFromUDP(r.buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.buf[:n], nil
+}
+
+// WritePcap write received packets to w in pcap format until receiver is closed,
+// link type of pcap is chosen by protocol of first packet, datagrams which are not valid TZSP are skipped
+func (r *TZSPReceiver) WritePcap(w io.Writer) error {
+	var pw *PcapWriter
+	for {
+		b, err := r.read()
+		if err != nil {
+			return err
+		}
+
+		p, err := DecodeTZSP(b)
+		if err != nil {
+			continue
+		}
+
+		if pw == nil {
+			if pw, err = NewPcapWriter(w, PcapLinkType(p.Protocol)); err != nil {
+				return err
+			}
+		}
+
+		if err := pw.WritePacket(p); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *TZSPReceiver) Close() error {
+	return r.conn.Close()
+}
+
+// pcap link types
+const (
+	PcapLinkTypeEthernet = 1
+	PcapLinkType80211    = 105
+)
+
+// PcapLinkType returns pcap link type of TZSP encapsulated protocol, ethernet is used for unknown protocols
+func PcapLinkType(protocol uint16) uint32 {
+	if protocol == TZSP80211 {
+		return PcapLinkType80211
+	}
+
+	return PcapLinkTypeEthernet
+}
+
+// PcapWriter write packets in pcap format
+type PcapWriter struct {
+	w io.Writer
+}
+
+// NewPcapWriter write pcap file header to w
+func NewPcapWriter(w io.Writer, linkType uint32) (*PcapWriter, error) {
+	hdr := make([]byte, 24)
+	binary.LittleEndian.PutUint32(hdr[0:], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(hdr[4:], 2)
+	binary.LittleEndian.PutUint16(hdr[6:], 4)
+	binary.LittleEndian.PutUint32(hdr[16:], 65535)
+	binary.LittleEndian.PutUint32(hdr[20:], linkType)
+
+	if _, err := w.Write(hdr); err != nil {
+		return nil, err
+	}
+
+	return &PcapWriter{w: w}, nil
+}
+
+func (pw *PcapWriter) WritePacket(p *TZSPPacket) error {
+	hdr := make([]byte, 16)
+	binary.LittleEndian.PutUint32(hdr[0:], uint32(p.Time.Unix()))
+	binary.LittleEndian.PutUint32(hdr[4:], uint32(p.Time.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(hdr[8:], uint32(len(p.Data)))
+	binary.LittleEndian.PutUint32(hdr[12:], uint32(len(p.Data)))
+
+	if _, err := pw.w.Write(hdr); err != nil {
+		return err
+	}
+
+	_, err := pw.w.Write(p.Data)
+	return err
+}
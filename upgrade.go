@@ -0,0 +1,129 @@
+package mikrotik
+
+import (
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+)
+
+// UpgradeProgress is passed to callback on each step of upgrade workflow
+type UpgradeProgress struct {
+	Stage   string
+	Message string
+}
+
+const (
+	UpgradeStageCheck     = "check"
+	UpgradeStagePackages  = "packages"
+	UpgradeStageReboot    = "reboot"
+	UpgradeStageFirmware  = "firmware"
+	UpgradeStageCompleted = "completed"
+)
+
+// Upgrade install latest packages from current update channel, wait until router come back
+// after reboot, then upgrade RouterBOARD firmware and reboot again to apply it.
+// Timeout is used for each wait of router after reboot, progress callback may be nil.
+func (s *system) Upgrade(timeout time.Duration, progress func(UpgradeProgress)) error {
+	report := func(stage, message string) {
+		if progress != nil {
+			progress(UpgradeProgress{Stage: stage, Message: message})
+		}
+	}
+
+	report(UpgradeStageCheck, "checking for updates")
+	update, err := s.Package.Update.CheckForUpdates()
+	if err != nil {
+		return err
+	}
+
+	if update.LatestVersion != "" && update.InstalledVersion != update.LatestVersion {
+		report(UpgradeStagePackages, "installing "+update.LatestVersion+" over "+update.InstalledVersion)
+		if err := s.Package.Update.Install(); err != nil && !isConnClosed(err) {
+			return err
+		}
+
+		report(UpgradeStageReboot, "waiting for router")
+		if err := s.waitReboot(timeout); err != nil {
+			return err
+		}
+	} else {
+		report(UpgradeStagePackages, "packages are up to date "+update.InstalledVersion)
+	}
+
+	rb, err := s.Routerboard.Get()
+	if err != nil {
+		return err
+	}
+
+	if !rb.Routerboard || rb.CurrentFirmware == rb.UpgradeFirmware {
+		report(UpgradeStageCompleted, "firmware is up to date "+rb.CurrentFirmware)
+		return nil
+	}
+
+	report(UpgradeStageFirmware, "upgrading firmware "+rb.CurrentFirmware+" to "+rb.UpgradeFirmware)
+	if err := s.Routerboard.Upgrade(); err != nil {
+		return err
+	}
+
+	if err := s.Reboot(); err != nil {
+		return err
+	}
+
+	report(UpgradeStageReboot, "waiting for router")
+	if err := s.waitReboot(timeout); err != nil {
+		return err
+	}
+
+	report(UpgradeStageCompleted, "upgrade completed")
+	return nil
+}
+
+// waitReboot wait until router stops accepting connections and then reconnect to it,
+// router is probed by dialing new connections, so half-open current connection does not block
+func (s *system) waitReboot(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if !s.probe() {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	for time.Now().Before(deadline) {
+		time.Sleep(5 * time.Second)
+		if !s.probe() {
+			continue
+		}
+
+		if err := s.mikrotik.Reconnect(); err == nil {
+			return nil
+		}
+	}
+
+	return errors.New("router is not available after reboot")
+}
+
+// probe returns true if router accepts connections on api port
+func (s *system) probe() bool {
+	conn, err := net.DialTimeout("tcp", s.mikrotik.addr, probeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+const probeTimeout = 3 * time.Second
+
+// isConnClosed returns true if error is caused by connection closed by router, it is expected on reboot,
+// timeouts are not treated as closed connection
+func isConnClosed(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
@@ -131,7 +131,7 @@ func (v Values) setStruct(rv reflect.Value, rt reflect.Type) (err error) {
 		var fieldname string
 
 		if tag, ok := tfield.Tag.Lookup("mikrotik"); ok {
-			fieldname = tag
+			fieldname = strings.Split(tag, ",")[0]
 		} else {
 			fieldname = ToMikrotikName(tfield.Name)
 		}
@@ -155,23 +155,42 @@ func (v Values) setStruct(rv reflect.Value, rt reflect.Type) (err error) {
 			vfield.SetBool(b)
 
 		case int:
+			// router returns empty value when it is unknown, like signal without registration
+			if val == "" {
+				continue
+			}
 			n, err := strconv.Atoi(val)
 			if err != nil {
 				return err
 			}
 			vfield.SetInt(int64(n))
 
+		case float64:
+			if val == "" {
+				continue
+			}
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return err
+			}
+			vfield.SetFloat(f)
+
 		case net.IP:
 			ip := net.ParseIP(val)
 			vfield.Set(reflect.ValueOf(ip))
 
 		case time.Duration:
-			dur, err := time.ParseDuration(val)
+			dur, err := ParseDuration(val)
 			if err != nil {
 				return err
 			}
 			vfield.Set(reflect.ValueOf(dur))
 
+		case []string:
+			if val != "" {
+				vfield.Set(reflect.ValueOf(strings.Split(val, ",")))
+			}
+
 		default:
 			fieldVal := reflect.ValueOf(val).Convert(vfield.Type())
 			vfield.Set(fieldVal)
@@ -181,6 +200,91 @@ func (v Values) setStruct(rv reflect.Value, rt reflect.Type) (err error) {
 	return nil
 }
 
+// ParseDuration parse duration in mikrotik format, like 1w2d3h4m5s or 1d12:30:00,
+// also accepts go duration format. Empty, never and timeout values are parsed as zero duration
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" || s == "never" || s == "timeout" {
+		return 0, nil
+	}
+
+	var dur time.Duration
+
+	// weeks and days are not supported by time.ParseDuration
+	for _, unit := range []struct {
+		suffix byte
+		dur    time.Duration
+	}{{'w', 7 * 24 * time.Hour}, {'d', 24 * time.Hour}} {
+		i := strings.IndexByte(s, unit.suffix)
+		if i < 0 {
+			continue
+		}
+
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		dur += time.Duration(n) * unit.dur
+		s = s[i+1:]
+	}
+
+	if s == "" {
+		return dur, nil
+	}
+
+	// hh:mm:ss[.fff]
+	if parts := strings.Split(s, ":"); len(parts) == 3 {
+		h, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		m, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		sec, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		dur += time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second))
+		return dur, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return dur + d, nil
+}
+
+// FormatDuration format duration to mikrotik format, like 1w2d3h4m5s
+func FormatDuration(dur time.Duration) string {
+	if dur == 0 {
+		return "0s"
+	}
+
+	var b strings.Builder
+	for _, unit := range []struct {
+		suffix string
+		dur    time.Duration
+	}{
+		{"w", 7 * 24 * time.Hour},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+	} {
+		if n := dur / unit.dur; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			dur -= n * unit.dur
+		}
+	}
+
+	return b.String()
+}
+
 //ToFieldName convert incoming fieldname to struct fieldname, example:
 // address -> Address
 // actual-interface -> ActualInterface
@@ -228,9 +332,10 @@ func ToArgs(i interface{}) (args []string) {
 		field := rv.Field(i)
 		structField := rt.Field(i)
 
-		// if !field.CanSet() {
-		// 	continue
-		// }
+		// skip unexported fields, like mikrotik in Ping
+		if structField.PkgPath != "" {
+			continue
+		}
 
 		if IsEmpty(field) {
 			continue
@@ -252,7 +357,14 @@ func ToArgs(i interface{}) (args []string) {
 			name = ToMikrotikName(structField.Name)
 		}
 
-		args = append(args, fmt.Sprintf("=%s=%v", name, field.Interface()))
+		switch v := field.Interface().(type) {
+		case time.Duration:
+			args = append(args, fmt.Sprintf("=%s=%s", name, FormatDuration(v)))
+		case []string:
+			args = append(args, fmt.Sprintf("=%s=%s", name, strings.Join(v, ",")))
+		default:
+			args = append(args, fmt.Sprintf("=%s=%v", name, v))
+		}
 	}
 
 	return
@@ -266,8 +378,14 @@ func IsEmpty(v reflect.Value) bool {
 		return v.Bool() == false
 	case int:
 		return v.Int() == 0
+	case float64:
+		return v.Float() == 0
 	case net.IP:
 		return v.Len() == 0
+	case time.Duration:
+		return v.Int() == 0
+	case []string:
+		return v.Len() == 0
 	}
 
 	return false